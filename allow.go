@@ -0,0 +1,203 @@
+package goclean
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AllowRule allows a detected word in circumstances that false positives, which are regexes applied to
+// the whole message, can not express, like "cock" followed by "pit" or "balls" in a sports channel.
+//
+// A rule allows a concern if its word matches and all of its conditions are met.
+type AllowRule struct {
+	// Name identifies the rule in the output of Explain.
+	Name string `json:"name,omitempty"`
+	// Word is the word of the WordMatcher the rule applies to, or the matched text for matchers without a word.
+	Word string `json:"word"`
+	// Preceding are strings one of which must directly precede the concern, in the same token ("cock" in "gamecock")
+	// or as the whole previous token ("cock" in "game cock"). Case is ignored.
+	Preceding []string `json:"preceding,omitempty"`
+	// Following are strings one of which must directly follow the concern, in the same token ("cock" in "cockpit")
+	// or as the whole next token ("cock" in "cock pit", but not in "cock pitbull"). Case is ignored.
+	Following []string `json:"following,omitempty"`
+	// Contexts are context tags, like channels, one of which must be passed in ListOptions.Contexts.
+	Contexts []string `json:"contexts,omitempty"`
+}
+
+// Explanation is the output of Explain.
+type Explanation struct {
+	// Concerns are the concerns returned by ListWithOptions.
+	Concerns []DetectedConcern
+	// Suppressed are the profanities matched in the message that are not concerns.
+	Suppressed []SuppressedConcern
+}
+
+// SuppressedConcern is a profanity that was matched, but is suppressed by an allow rule or a false positive.
+type SuppressedConcern struct {
+	DetectedConcern
+	// Rule is the name of the AllowRule, or "allowRules[i]" for rules without a name.
+	Rule string
+	// FalsePositive is the false positive regex that matched the profanity.
+	FalsePositive string
+}
+
+// Explain detects profanities like ListWithOptions and also reports the matches that are suppressed, with the
+// allow rule or the false positive that suppressed them. It's slower than List and meant for moderator tools.
+func (gc *ProfanitySanitizer) Explain(message string, opts ListOptions) Explanation {
+	s := getScratch()
+	defer putScratch(s)
+	sanitized := s.sanitize(message, gc.config.InvalidUTF8)
+	var explanation Explanation
+	explanation.Concerns, _ = gc.detectIn(context.Background(), make([]DetectedConcern, 0), sanitized, &s.matched,
+		detectOptions{contexts: opts.Contexts, suppressed: &explanation.Suppressed})
+
+	var explained spanSet
+	for _, concern := range explanation.Concerns {
+		explained.add(int(concern.StartIndex), int(concern.EndIndex))
+	}
+	for _, suppressed := range explanation.Suppressed {
+		explained.add(int(suppressed.StartIndex), int(suppressed.EndIndex))
+	}
+	for _, profanity := range gc.config.Profanities {
+		if profanity.Matcher == nil || !profanity.Matcher.MatchString(sanitized) {
+			continue
+		}
+		for _, index := range profanity.Matcher.FindAllStringIndex(sanitized, -1) {
+			start, end := index[0], index[1]
+			if profanity.Kind == KindAcronym && !isWholeToken(sanitized, start, end) || explained.isAlreadyMatched(start, end) {
+				continue
+			}
+			if falsePositive := gc.falsePositiveAt(sanitized, start, end); falsePositive != "" {
+				explanation.Suppressed = append(explanation.Suppressed, SuppressedConcern{
+					DetectedConcern: newConcern(profanity, sanitized, start, end),
+					FalsePositive:   falsePositive,
+				})
+				explained.add(start, end)
+			}
+		}
+	}
+
+	if opts.RuneOffsets || opts.UTF16Offsets || opts.GraphemeOffsets {
+		setOffsets(sanitized, explanation.Concerns, opts)
+		suppressed := make([]DetectedConcern, len(explanation.Suppressed))
+		for i := range explanation.Suppressed {
+			suppressed[i] = explanation.Suppressed[i].DetectedConcern
+		}
+		setOffsets(sanitized, suppressed, opts)
+		for i := range explanation.Suppressed {
+			explanation.Suppressed[i].DetectedConcern = suppressed[i]
+		}
+	}
+	return explanation
+}
+
+// falsePositiveAt returns the false positive regex whose match overlaps [start, end), or an empty string.
+func (gc *ProfanitySanitizer) falsePositiveAt(message string, start, end int) string {
+	for _, falsePositive := range gc.falsePositives {
+		for _, index := range falsePositive.FindAllStringIndex(message, -1) {
+			if index[0] < end && start < index[1] {
+				return falsePositive.String()
+			}
+		}
+	}
+	return ""
+}
+
+// applyAllowRules removes the concerns allowed by the allow rules, and adds them to suppressed if it's not nil.
+func (gc *ProfanitySanitizer) applyAllowRules(concerns []DetectedConcern, message string, contexts []string, suppressed *[]SuppressedConcern) []DetectedConcern {
+	kept := concerns[:0]
+	for _, concern := range concerns {
+		rule := gc.allowRule(concern, message, contexts)
+		if rule < 0 {
+			kept = append(kept, concern)
+			continue
+		}
+		if suppressed != nil {
+			name := gc.config.AllowRules[rule].Name
+			if name == "" {
+				name = fmt.Sprintf("allowRules[%d]", rule)
+			}
+			*suppressed = append(*suppressed, SuppressedConcern{DetectedConcern: concern, Rule: name})
+		}
+	}
+	return kept
+}
+
+// allowRule returns the index of the first rule that allows the concern, or -1.
+func (gc *ProfanitySanitizer) allowRule(concern DetectedConcern, message string, contexts []string) int {
+	word := concern.Word
+	if word == "" {
+		word = concern.MatchedText
+	}
+	for i, rule := range gc.config.AllowRules {
+		if !strings.EqualFold(rule.Word, word) {
+			continue
+		}
+		if len(rule.Contexts) > 0 && !containsAny(rule.Contexts, contexts) {
+			continue
+		}
+		if len(rule.Preceding) > 0 && !isPrecededBy(message[:concern.StartIndex], rule.Preceding) {
+			continue
+		}
+		if len(rule.Following) > 0 && !isFollowedBy(message[concern.EndIndex:], rule.Following) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// isPrecededBy reports whether the text before a concern ends with one of the tokens, or whether the previous
+// token, before spaces, is one of them.
+func isPrecededBy(before string, tokens []string) bool {
+	trimmed := strings.TrimRight(before, " \t\r\n")
+	for _, token := range tokens {
+		if hasSuffixFold(before, token) {
+			return true
+		}
+		if trimmed != before && hasSuffixFold(trimmed, token) && isWholeToken(trimmed, len(trimmed)-len(token), len(trimmed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFollowedBy reports whether the text after a concern starts with one of the tokens, or whether the next
+// token, after spaces, is one of them.
+func isFollowedBy(after string, tokens []string) bool {
+	trimmed := strings.TrimLeft(after, " \t\r\n")
+	for _, token := range tokens {
+		if hasPrefixFold(after, token) {
+			return true
+		}
+		if trimmed != after && hasPrefixFold(trimmed, token) && isWholeToken(trimmed, 0, len(token)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func hasSuffixFold(s, suffix string) bool {
+	return len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix)
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, value := range wanted {
+		if containsString(values, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain detects profanities and reports the matches that are suppressed by allow rules or false positives.
+//
+// Uses the default ProfanitySanitizer
+func Explain(message string, opts ListOptions) Explanation {
+	return gc.Explain(message, opts)
+}
@@ -0,0 +1,87 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_AllowRules(t *testing.T) {
+	c := DefaultConfig()
+	c.AllowRules = []AllowRule{
+		{Name: "cock compounds", Word: "cock", Following: []string{"pit", "roach"}},
+		{Word: "ass", Preceding: []string{"kick"}},
+		{Name: "sports", Word: "balls", Contexts: []string{"sports", "golf"}},
+		{Name: "regex", Word: "fuuuck"},
+	}
+	sanitizer := NewProfanitySanitizer(c)
+	tests := []struct {
+		name     string
+		text     string
+		contexts []string
+		want     bool
+	}{
+		{"word", "cock", nil, true},
+		{"followed in the same token", "Cockpit", nil, false},
+		{"followed by the next token", "cock roach", nil, false},
+		{"followed by another word", "cock fight", nil, true},
+		{"next token starts with the string", "cock pitiful", nil, true},
+		{"next token is a compound", "cock pitbull", nil, true},
+		{"preceded", "kickass", nil, false},
+		{"preceded by the previous token", "kick ass", nil, false},
+		{"previous token ends with the string", "sidekick ass", nil, true},
+		{"not preceded", "smartass", nil, true},
+		{"context", "nice balls", []string{"golf"}, false},
+		{"other context", "nice balls", []string{"chat"}, true},
+		{"no context", "nice balls", nil, true},
+		{"matched text of a regex", "fuuuck", nil, false},
+		{"only the allowed concern", "cockpit cock", nil, true},
+		{"several allowed concerns", "cockpit kickass cockroach", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := len(sanitizer.ListWithOptions(test.text, ListOptions{Contexts: test.contexts})) > 0
+			if got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+			if test.contexts == nil && sanitizer.IsProfane(test.text) != test.want {
+				t.Errorf("IsProfane should match List")
+			}
+		})
+	}
+	if got, want := sanitizer.Redact("cockpit cock"), "cockpit ****"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestGoClean_Explain(t *testing.T) {
+	c := DefaultConfig()
+	c.AllowRules = []AllowRule{{Word: "balls", Contexts: []string{"sports"}}}
+	sanitizer := NewProfanitySanitizer(c)
+	got := sanitizer.Explain("bass balls ass", ListOptions{Contexts: []string{"sports"}, RuneOffsets: true})
+	want := Explanation{
+		Concerns: []DetectedConcern{{Word: "ass", MatchedText: "ass", StartIndex: 11, EndIndex: 14, Level: 2, RuneStart: 11, RuneEnd: 14}},
+		Suppressed: []SuppressedConcern{
+			{DetectedConcern: DetectedConcern{Word: "balls", MatchedText: "balls", StartIndex: 5, EndIndex: 10, RuneStart: 5, RuneEnd: 10}, Rule: "allowRules[0]"},
+			{DetectedConcern: DetectedConcern{Word: "ass", MatchedText: "ass", StartIndex: 1, EndIndex: 4, Level: 2, RuneStart: 1, RuneEnd: 4}, FalsePositive: "bass"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if got := Explain("cockpit", ListOptions{}); len(got.Concerns) != 0 || len(got.Suppressed) != 1 || got.Suppressed[0].Rule != "cock compounds" {
+		t.Errorf("got %+v, want cock suppressed by the default rule", got)
+	}
+}
+
+func TestParseConfig_AllowRules(t *testing.T) {
+	data := "allowRules:\n  - name: cockpit\n    word: cock\n    following: [pit]\n    contexts: [aviation]\n"
+	got, err := ParseConfig([]byte(data), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []AllowRule{{Name: "cockpit", Word: "cock", Following: []string{"pit"}, Contexts: []string{"aviation"}}}
+	if !reflect.DeepEqual(got.AllowRules, want) {
+		t.Errorf("got %+v, want %+v", got.AllowRules, want)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+func runDiscover(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("discover", "[word list]", stderr)
+	configPath := flags.String("config", "config.json", "configuration to check")
+	emit := flags.Bool("emit", false, "print the suggested false positives in the config format")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	c, err := goclean.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	words := goclean.EnglishWords()
+	if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer file.Close()
+		words = file
+	}
+	sanitizer := goclean.NewProfanitySanitizer(c)
+	candidates, err := sanitizer.DiscoverFalsePositives(words)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *emit {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(struct {
+			FalsePositives []string `json:"falsePositives"`
+		}{goclean.SuggestFalsePositives(candidates)}); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		return 0
+	}
+	for _, candidate := range candidates {
+		for _, match := range candidate.Matches {
+			matcher := "unknown matcher"
+			if m := match.Matcher; m != nil {
+				matcher = fmt.Sprintf("word %q", m.Word)
+				if m.Regex != "" {
+					matcher = fmt.Sprintf("regex %q", m.Regex)
+				}
+			}
+			fmt.Fprintf(stdout, "%s: %q matched by %s\n", candidate.Word, match.Concern.MatchedText, matcher)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	goclean "github.com/martinhrvn/go-clean"
+	"github.com/martinhrvn/go-clean/eval"
+)
+
+func runEval(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("eval", "<dataset.jsonl>", stderr)
+	configPath := flags.String("config", "config.json", "configuration to evaluate")
+	baselinePath := flags.String("baseline", "", "configuration to compare with, e.g. config.json before a change")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer file.Close()
+	dataset, err := eval.ReadDataset(file)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	report, err := evaluate(*configPath, dataset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *baselinePath == "" {
+		if err := report.Write(stdout); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		return 0
+	}
+	baseline, err := evaluate(*baselinePath, dataset)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	diff, err := eval.Compare(baseline, report)
+	if err == nil {
+		err = diff.Write(stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func evaluate(configPath string, dataset []eval.Example) (*eval.Report, error) {
+	c, err := goclean.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	sanitizer := goclean.NewProfanitySanitizer(c)
+	return eval.Evaluate(&sanitizer, dataset), nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+func runImport(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("import", "<word list>", stderr)
+	configPath := flags.String("config", "", "configuration to add the words to (default: empty configuration)")
+	format := flags.String("format", "", "format of the word list: text, csv or json (default: detected from the extension)")
+	output := flags.String("o", "", "write the configuration to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	path := flags.Arg(0)
+
+	importFormat := goclean.DetectImportFormat(path)
+	if *format != "" {
+		var err error
+		if importFormat, err = goclean.ParseImportFormat(*format); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+	c, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer file.Close()
+	report, err := goclean.Import(c, file, importFormat)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", path, err)
+		return 1
+	}
+	if err := writeConfig(*output, c, stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "added %d words and %d false positives, skipped %d duplicates\n",
+		len(report.Added), len(report.AddedFalsePositives), len(report.Duplicates))
+	for _, issue := range report.Skipped {
+		fmt.Fprintf(stderr, "%s:%d: could not convert %q: %s\n", path, issue.Line, issue.Entry, issue.Reason)
+	}
+	return 0
+}
+
+// loadConfig loads the configuration at path, or returns an empty configuration with default options.
+func loadConfig(path string) (*goclean.Config, error) {
+	if path == "" {
+		return goclean.ParseConfig([]byte("{}"), goclean.FormatJSON)
+	}
+	return goclean.LoadConfig(path)
+}
+
+func writeConfig(path string, c *goclean.Config, stdout io.Writer) error {
+	if path == "" {
+		return goclean.WriteConfig(stdout, c)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := goclean.WriteConfig(file, c); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+func runLint(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("lint", "[config]", stderr)
+	strict := flags.Bool("strict", false, "exit with an error status on warnings too")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	path := "config.json"
+	if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+	c, err := goclean.LoadConfig(path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	issues := goclean.Lint(c)
+	failed := false
+	for _, issue := range issues {
+		fmt.Fprintf(stdout, "%s: %s\n", path, issue)
+		if issue.Severity == goclean.LintError || *strict {
+			failed = true
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
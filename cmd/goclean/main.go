@@ -0,0 +1,73 @@
+// Command goclean manages go-clean dictionaries.
+//
+// Usage:
+//
+//	goclean <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	import    convert a word list and add it to a configuration
+//	lint      check a configuration for conflicts and dangerous patterns
+//	discover  find clean words that are detected as profane
+//	eval      measure precision and recall on a labelled dataset
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"import", "convert a word list and add it to a configuration", runImport},
+	{"lint", "check a configuration for conflicts and dangerous patterns", runLint},
+	{"discover", "find clean words that are detected as profane", runDiscover},
+	{"eval", "measure precision and recall on a labelled dataset", runEval},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdout, stderr)
+		}
+	}
+	if args[0] != "help" && args[0] != "-h" && args[0] != "--help" {
+		fmt.Fprintf(stderr, "goclean: unknown command %q\n", args[0])
+	}
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: goclean <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s%s\n", c.name, c.usage)
+	}
+}
+
+func newFlagSet(name, arguments string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: goclean %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("got exit code %d, want 2", code)
+	}
+	if code := run([]string{"unknown"}, &stdout, &stderr); code != 2 {
+		t.Errorf("got exit code %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "import") {
+		t.Errorf("usage should list the commands, got %s", stderr.String())
+	}
+}
+
+func TestRun_Import(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"import", "-config", "../../testdata/config.yaml", "../../testdata/import/words.csv"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	c := &goclean.Config{}
+	if err := json.Unmarshal(stdout.Bytes(), c); err != nil {
+		t.Fatalf("output should be a JSON config: %v", err)
+	}
+	if len(c.Profanities) != 5 || c.Profanities[3].Word != "heck" {
+		t.Errorf("got profanities %v", c.Profanities)
+	}
+	if !strings.Contains(stderr.String(), "added 2 words") || !strings.Contains(stderr.String(), `words.csv:5: could not convert`) {
+		t.Errorf("got report %s", stderr.String())
+	}
+}
+
+func TestRun_ImportToFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	output := filepath.Join(t.TempDir(), "config.json")
+	code := run([]string{"import", "-format", "ldnoobw", "-o", output, "../../testdata/import/ldnoobw.txt"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	c, err := goclean.LoadConfig(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Profanities) != 5 || !c.DetectLeetSpeak {
+		t.Errorf("got %+v", c)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("nothing should be written to stdout, got %s", stdout.String())
+	}
+}
+
+func TestRun_Lint(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lint", "../../testdata/config.yaml"}, &stdout, &stderr); code != 0 {
+		t.Errorf("got exit code %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	code := run([]string{"lint", "-strict", "../../config.json"}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("got exit code %d, want 1", code)
+	}
+	if !strings.Contains(stdout.String(), `../../config.json: warning: falseNegatives[2] "dumbass": duplicate of falseNegatives[0] "dumbass" (duplicate)`) {
+		t.Errorf("got %s", stdout.String())
+	}
+}
+
+func TestRun_Discover(t *testing.T) {
+	words := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(words, []byte("hello\nbrass\nbass\nskyscraper\n🖕 brass\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"discover", "-config", "../../config.json", words}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	want := "brass: \"ass\" matched by word \"ass\"\nskyscraper: \"crap\" matched by word \"crap\"\n" +
+		"🖕 brass: \"ass\" matched by word \"ass\"\n🖕 brass: \"🖕\" matched by word \"🖕\"\n"
+	if stdout.String() != want {
+		t.Errorf("got %q, want %q", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"discover", "-config", "../../config.json", "-emit", words}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	c, err := goclean.ParseConfig(stdout.Bytes(), goclean.FormatJSON)
+	if err != nil {
+		t.Fatalf("output should be a config: %v", err)
+	}
+	if len(c.FalsePositives) != 3 || c.FalsePositives[0] != "brass" {
+		t.Errorf("got %v", c.FalsePositives)
+	}
+}
+
+func TestRun_Eval(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"eval", "-config", "../../testdata/config.yaml", "../../eval/testdata/dataset.jsonl"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "messages") || !strings.Contains(stdout.String(), "crap") {
+		t.Errorf("got %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"eval", "-config", "../../config.json", "-baseline", "../../testdata/config.yaml", "../../eval/testdata/dataset.jsonl"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "fixed (2):\n  example 4: \"this is crap\"\n  example 5: \"classic music\"") {
+		t.Errorf("got %s", stdout.String())
+	}
+}
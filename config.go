@@ -3,17 +3,52 @@ package goclean
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"regexp"
+	"regexp/syntax"
 	"strings"
+	"unicode/utf8"
 )
 
-// WordMatcher is a struct that contains the word or regex to be matched and the level of the word.
+// WordMatcher is a struct that contains the word or regex to be matched, the level and the optional category of the word.
 type WordMatcher struct {
-	Word    string `json:"word,omitempty"`
-	Regex   string `json:"regex,omitempty"`
-	Level   int32  `json:"level,omitempty,default=1"`
-	Matcher *regexp.Regexp
+	Word     string `json:"word,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+	Level    int32  `json:"level,omitempty,default=1"`
+	Category string `json:"category,omitempty"`
+	// Kind selects the matching rules of the word, see MatcherKind.
+	Kind MatcherKind `json:"kind,omitempty"`
+	// Expansion is what the word stands for, e.g. for acronyms. It's reported in DetectedConcern.
+	Expansion string         `json:"expansion,omitempty"`
+	Matcher   *regexp.Regexp `json:"-"`
+	// maxLength is the maximum length of a match of Matcher in bytes, or 0 if it's not bounded, see maxMatchLength.
+	maxLength int
+}
+
+// MatcherKind selects the matching rules of a WordMatcher.
+type MatcherKind string
+
+const (
+	// KindWord matches the word with leet speak and obfuscation, also inside other words. It's the default kind.
+	KindWord MatcherKind = "word"
+	// KindAcronym matches the acronym case-insensitively, optionally with dots between the letters ("w.t.f"),
+	// but only as a whole token, so "wtf" does not match in "awtfb". Leet speak and obfuscation do not apply.
+	KindAcronym MatcherKind = "acronym"
+)
+
+// UnmarshalJSON decodes the kind and rejects unknown values.
+func (k *MatcherKind) UnmarshalJSON(data []byte) error {
+	var kind string
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return err
+	}
+	switch MatcherKind(kind) {
+	case "", KindWord, KindAcronym:
+		*k = MatcherKind(kind)
+		return nil
+	}
+	return fmt.Errorf("goclean: unknown matcher kind %q", kind)
 }
 
 // Config is a struct that contains the configuration for the profanity sanitizer.
@@ -22,10 +57,64 @@ type Config struct {
 	DetectObfuscated     bool   `json:"detectObfuscated"`
 	ReplacementCharacter string `json:"replacementCharacter"`
 	ObfuscationLength    int32  `json:"obfuscationLength,default=3"`
+	// MaxInputLength is the maximum length of a message in bytes accepted by the
+	// context aware methods (ListContext, RedactContext, IsProfaneContext).
+	// Zero means there is no limit.
+	MaxInputLength int `json:"maxInputLength,omitempty"`
+	// InvalidUTF8 defines how invalid UTF-8 sequences in messages are handled, see InvalidUTF8Policy.
+	InvalidUTF8 InvalidUTF8Policy `json:"invalidUTF8,omitempty"`
 
 	Profanities    []WordMatcher `json:"profanities"`
 	FalsePositives []string      `json:"falsePositives"`
 	FalseNegatives []WordMatcher `json:"falseNegatives"`
+	// Emoji are emoji sequences ("🖕", "🍆💦") and emoticons ("8==D") matched on grapheme cluster boundaries,
+	// see compileEmoji.
+	Emoji []WordMatcher `json:"emoji,omitempty"`
+	// AllowRules allow detected words depending on the surrounding text and the context of the message.
+	AllowRules []AllowRule `json:"allowRules,omitempty"`
+	// Synonyms are the clean replacements of profanities used by SuggestAlternatives, by the lowercase word.
+	Synonyms map[string][]string `json:"synonyms,omitempty"`
+}
+
+// InvalidUTF8Policy defines how invalid UTF-8 sequences in messages are handled.
+//
+// Detected concerns and redacted messages always refer to the message after the policy is applied,
+// so they are valid UTF-8.
+type InvalidUTF8Policy string
+
+const (
+	// InvalidUTF8Replace replaces every invalid byte with U+FFFD, it's the default policy.
+	InvalidUTF8Replace InvalidUTF8Policy = "replace"
+	// InvalidUTF8Drop removes invalid bytes from the message.
+	InvalidUTF8Drop InvalidUTF8Policy = "drop"
+	// InvalidUTF8Reject makes the context aware methods (ListContext, RedactContext, IsProfaneContext)
+	// return *InvalidUTF8Error. Other methods replace invalid bytes, like InvalidUTF8Replace.
+	InvalidUTF8Reject InvalidUTF8Policy = "reject"
+)
+
+// UnmarshalJSON decodes the policy and rejects unknown values.
+func (p *InvalidUTF8Policy) UnmarshalJSON(data []byte) error {
+	var policy string
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return err
+	}
+	switch InvalidUTF8Policy(policy) {
+	case "", InvalidUTF8Replace, InvalidUTF8Drop, InvalidUTF8Reject:
+		*p = InvalidUTF8Policy(policy)
+		return nil
+	}
+	return fmt.Errorf("goclean: unknown invalid UTF-8 policy %q", policy)
+}
+
+// UnmarshalJSON decodes a WordMatcher from an object, or from a string that is used as its word.
+func (m *WordMatcher) UnmarshalJSON(data []byte) error {
+	var word string
+	if err := json.Unmarshal(data, &word); err == nil {
+		*m = WordMatcher{Word: word}
+		return nil
+	}
+	type wordMatcher WordMatcher
+	return json.Unmarshal(data, (*wordMatcher)(m))
 }
 
 var leetSpeakMapping = map[string]string{
@@ -34,26 +123,170 @@ var leetSpeakMapping = map[string]string{
 }
 
 func (c *Config) initializeMatchers(matchers []WordMatcher) []WordMatcher {
+	if err := c.compileMatchers(matchers); err != nil {
+		panic(err)
+	}
+	return matchers
+}
+
+// compileMatchers compiles the matchers in place and returns the first invalid regex error.
+func (c *Config) compileMatchers(matchers []WordMatcher) error {
 	for i, m := range matchers {
-		if m.Regex != "" {
-			matchers[i].Matcher = regexp.MustCompile("(?i)" + m.Regex)
-		} else if m.Word != "" {
-			split := strings.Split(m.Word, "")
-			c.replaceLeetSpeak(split)
-			c.configureDetectObfuscated(matchers, split, i)
+		matcher, err := c.compileMatcher(m)
+		if err != nil {
+			return err
 		}
+		matchers[i].Matcher = matcher
+		matchers[i].maxLength = maxMatchLength(matcher)
+	}
+	return nil
+}
+
+func initializeEmoji(matchers []WordMatcher) []WordMatcher {
+	if err := compileEmojiMatchers(matchers); err != nil {
+		panic(err)
 	}
 	return matchers
 }
 
-func (c *Config) configureDetectObfuscated(matchers []WordMatcher, split []string, i int) {
+// compileEmojiMatchers compiles the emoji matchers in place and returns the first invalid regex error.
+func compileEmojiMatchers(matchers []WordMatcher) error {
+	for i, m := range matchers {
+		matcher, err := compileEmoji(m)
+		if err != nil {
+			return err
+		}
+		matchers[i].Matcher = matcher
+	}
+	return nil
+}
+
+// compileEmoji compiles the regex of an emoji WordMatcher, or builds one from its word. Leet speak and obfuscation
+// do not apply to emoji. Variation selectors are removed from the word, like from messages, and an emoji without
+// a skin tone modifier matches all skin tones, while an emoji with a modifier only matches that skin tone.
+func compileEmoji(m WordMatcher) (*regexp.Regexp, error) {
+	if m.Regex != "" {
+		return regexp.Compile(m.Regex)
+	}
+	if m.Word == "" {
+		return nil, nil
+	}
+	var pattern strings.Builder
+	runes := []rune(m.Word)
+	for i, r := range runes {
+		if r >= 0xFE00 && r <= 0xFE0F {
+			continue
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(r)))
+		if isPictographic(r) && (i+1 == len(runes) || !isEmojiModifier(runes[i+1])) {
+			pattern.WriteString(`[\x{1F3FB}-\x{1F3FF}]?`)
+		}
+	}
+	return regexp.Compile(pattern.String())
+}
+
+// compileMatcher compiles the regex of the WordMatcher, or builds one from its word
+// according to the leet speak and obfuscation settings. It returns nil if the matcher is empty.
+func (c *Config) compileMatcher(m WordMatcher) (*regexp.Regexp, error) {
+	if m.Regex != "" {
+		return regexp.Compile("(?i)" + m.Regex)
+	}
+	if m.Word == "" {
+		return nil, nil
+	}
+	if m.Kind == KindAcronym {
+		return compileAcronym(m.Word)
+	}
+	split := strings.Split(m.Word, "")
+	c.replaceLeetSpeak(split)
+	return regexp.Compile("(?i)" + c.joinObfuscated(split))
+}
+
+// maxMatchLength returns the maximum length of a match of the regex in bytes. It's 0 if the length is not bounded,
+// if the regex can match an empty string or if it contains assertions like "^" or "\b", which can match differently
+// in a part of a message, so the message can't be scanned in chunks.
+func maxMatchLength(re *regexp.Regexp) int {
+	if re == nil {
+		return 0
+	}
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return 0
+	}
+	min, max := matchRunes(parsed)
+	if min == 0 || max < 0 {
+		return 0
+	}
+	return max * utf8.UTFMax
+}
+
+// matchRunes returns the minimum and maximum number of runes matched by the regex, max is -1 if it's not bounded
+// or the regex contains assertions.
+func matchRunes(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpNoMatch, syntax.OpEmptyMatch:
+		return 0, 0
+	case syntax.OpLiteral:
+		return len(re.Rune), len(re.Rune)
+	case syntax.OpCharClass, syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return 1, 1
+	case syntax.OpCapture:
+		return matchRunes(re.Sub[0])
+	case syntax.OpQuest:
+		_, max := matchRunes(re.Sub[0])
+		return 0, max
+	case syntax.OpRepeat:
+		min, max := matchRunes(re.Sub[0])
+		if max < 0 || re.Max < 0 {
+			return min * re.Min, -1
+		}
+		return min * re.Min, max * re.Max
+	case syntax.OpConcat:
+		min, max := 0, 0
+		for _, sub := range re.Sub {
+			subMin, subMax := matchRunes(sub)
+			if subMax < 0 {
+				max = -1
+			} else if max >= 0 {
+				max += subMax
+			}
+			min += subMin
+		}
+		return min, max
+	case syntax.OpAlternate:
+		min, max := -1, 0
+		for _, sub := range re.Sub {
+			subMin, subMax := matchRunes(sub)
+			if min < 0 || subMin < min {
+				min = subMin
+			}
+			if subMax < 0 || max < 0 {
+				max = -1
+			} else if subMax > max {
+				max = subMax
+			}
+		}
+		return min, max
+	}
+	// OpStar, OpPlus and the assertions
+	return 0, -1
+}
+
+// compileAcronym builds the regex of an acronym, with optional dots between the letters.
+// Dots in the word itself are ignored, so "w.t.f" and "wtf" are the same acronym.
+func compileAcronym(word string) (*regexp.Regexp, error) {
+	letters := make([]string, 0, len(word))
+	for _, r := range strings.ReplaceAll(word, ".", "") {
+		letters = append(letters, regexp.QuoteMeta(string(r)))
+	}
+	return regexp.Compile(`(?i)` + strings.Join(letters, `\.?`))
+}
+
+func (c *Config) joinObfuscated(split []string) string {
 	if c.DetectObfuscated {
-		str := strings.Join(split, fmt.Sprintf("\\W{0,%d}", c.ObfuscationLength))
-		matchers[i].Matcher = regexp.MustCompile("(?i)" + str)
-	} else {
-		str := strings.Join(split, "")
-		matchers[i].Matcher = regexp.MustCompile("(?i)" + str)
+		return strings.Join(split, fmt.Sprintf("\\W{0,%d}", c.ObfuscationLength))
 	}
+	return strings.Join(split, "")
 }
 
 func (c *Config) replaceLeetSpeak(chars []string) {
@@ -68,10 +301,19 @@ func (c *Config) replaceLeetSpeak(chars []string) {
 	}
 }
 
+// WriteConfig writes the configuration in the config.json format.
+func WriteConfig(w io.Writer, c *Config) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
+
 // DefaultConfig is the default configuration for the profanity sanitizer.
+//
+// Options missing from config.json are set to their documented defaults, like LoadConfig does.
 func DefaultConfig() *Config {
 	file, _ := ioutil.ReadFile("config.json")
-	config := &Config{}
+	config := newConfig()
 	_ = json.Unmarshal(file, config)
 	return config
 }
@@ -0,0 +1,100 @@
+package goclean
+
+import (
+	"context"
+	"fmt"
+)
+
+// InputTooLongError is returned by the context aware methods when the message
+// is longer than Config.MaxInputLength.
+type InputTooLongError struct {
+	Length    int
+	MaxLength int
+}
+
+func (e *InputTooLongError) Error() string {
+	return fmt.Sprintf("goclean: input length %d exceeds maximum of %d bytes", e.Length, e.MaxLength)
+}
+
+// InvalidUTF8Error is returned by the context aware methods when the message is not valid UTF-8
+// and Config.InvalidUTF8 is InvalidUTF8Reject.
+type InvalidUTF8Error struct {
+	// Offset is the byte offset of the first invalid sequence.
+	Offset int
+}
+
+func (e *InvalidUTF8Error) Error() string {
+	return fmt.Sprintf("goclean: invalid UTF-8 at byte offset %d", e.Offset)
+}
+
+// ListContext works like List, but checks the context between matchers and between chunks of long
+// messages, and returns ctx.Err() as soon as the context is cancelled or its deadline is exceeded.
+//
+// If Config.MaxInputLength is set and the message is longer, *InputTooLongError is returned.
+// If Config.InvalidUTF8 is InvalidUTF8Reject and the message is not valid UTF-8, *InvalidUTF8Error is returned.
+func (gc *ProfanitySanitizer) ListContext(ctx context.Context, message string) ([]DetectedConcern, error) {
+	if err := gc.checkInput(ctx, message); err != nil {
+		return nil, err
+	}
+	return gc.list(ctx, message)
+}
+
+// RedactContext works like Redact, but checks the context between matchers and between chunks of long
+// messages, and returns ctx.Err() as soon as the context is cancelled or its deadline is exceeded.
+//
+// If Config.MaxInputLength is set and the message is longer, *InputTooLongError is returned.
+// If Config.InvalidUTF8 is InvalidUTF8Reject and the message is not valid UTF-8, *InvalidUTF8Error is returned.
+func (gc *ProfanitySanitizer) RedactContext(ctx context.Context, str string) (string, error) {
+	if err := gc.checkInput(ctx, str); err != nil {
+		return "", err
+	}
+	return gc.redact(ctx, str)
+}
+
+// IsProfaneContext works like IsProfane, but checks the context between matchers and between chunks of long
+// messages, and returns ctx.Err() as soon as the context is cancelled or its deadline is exceeded.
+//
+// If Config.MaxInputLength is set and the message is longer, *InputTooLongError is returned.
+// If Config.InvalidUTF8 is InvalidUTF8Reject and the message is not valid UTF-8, *InvalidUTF8Error is returned.
+func (gc *ProfanitySanitizer) IsProfaneContext(ctx context.Context, str string) (bool, error) {
+	if err := gc.checkInput(ctx, str); err != nil {
+		return false, err
+	}
+	return gc.isProfane(ctx, str)
+}
+
+func (gc *ProfanitySanitizer) checkInput(ctx context.Context, message string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if gc.config.MaxInputLength > 0 && len(message) > gc.config.MaxInputLength {
+		return &InputTooLongError{Length: len(message), MaxLength: gc.config.MaxInputLength}
+	}
+	if gc.config.InvalidUTF8 == InvalidUTF8Reject {
+		if offset := invalidUTF8Offset(message); offset >= 0 {
+			return &InvalidUTF8Error{Offset: offset}
+		}
+	}
+	return nil
+}
+
+// ListContext works like List, but can be cancelled using the context.
+//
+// Uses the default ProfanitySanitizer
+func ListContext(ctx context.Context, str string) ([]DetectedConcern, error) {
+	return gc.ListContext(ctx, str)
+}
+
+// RedactContext works like Redact, but can be cancelled using the context.
+//
+// Uses the default ProfanitySanitizer
+func RedactContext(ctx context.Context, str string) (string, error) {
+	return gc.RedactContext(ctx, str)
+}
+
+// IsProfaneContext works like IsProfane, but can be cancelled using the context.
+//
+// Uses the default ProfanitySanitizer
+func IsProfaneContext(ctx context.Context, str string) (bool, error) {
+	return gc.IsProfaneContext(ctx, str)
+}
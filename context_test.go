@@ -0,0 +1,170 @@
+package goclean
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGoClean_ListContext(t *testing.T) {
+	got, err := ListContext(context.Background(), "hello world fuck")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []DetectedConcern{{MatchedText: "fuck", StartIndex: 12, EndIndex: 16}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGoClean_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ListContext(ctx, "hello world fuck"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListContext: got %v, want %v", err, context.Canceled)
+	}
+	if _, err := RedactContext(ctx, "hello world fuck"); !errors.Is(err, context.Canceled) {
+		t.Errorf("RedactContext: got %v, want %v", err, context.Canceled)
+	}
+	if _, err := IsProfaneContext(ctx, "hello world fuck"); !errors.Is(err, context.Canceled) {
+		t.Errorf("IsProfaneContext: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestGoClean_MaxInputLength(t *testing.T) {
+	c := DefaultConfig()
+	c.MaxInputLength = 10
+	sanitizer := NewProfanitySanitizer(c)
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"shorter than limit", "shit", "****", false},
+		{"equal to limit", "shit shit!", "**** ****!", false},
+		{"longer than limit", "hello world fuck", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizer.RedactContext(context.Background(), test.text)
+			var tooLong *InputTooLongError
+			if errors.As(err, &tooLong) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if test.wantErr && (tooLong.Length != len(test.text) || tooLong.MaxLength != 10) {
+				t.Errorf("got %+v", tooLong)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+	if !sanitizer.IsProfane("hello world fuck") {
+		t.Errorf("IsProfane should not be limited by MaxInputLength")
+	}
+}
+
+func TestGoClean_InvalidUTF8(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  InvalidUTF8Policy
+		text    string
+		want    string
+		wantErr int
+	}{
+		{"valid", InvalidUTF8Reject, "fûçk", "****", -1},
+		{"replace", InvalidUTF8Replace, "shit\xff ass", "****� ***", -1},
+		{"default replaces", "", "\xffshit", "�****", -1},
+		{"drop", InvalidUTF8Drop, "sh\xffit \xe2\x80ass", "**** ***", -1},
+		{"reject", InvalidUTF8Reject, "shit \xe2\x80ass", "", 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := DefaultConfig()
+			c.InvalidUTF8 = test.policy
+			sanitizer := NewProfanitySanitizer(c)
+			got, err := sanitizer.RedactContext(context.Background(), test.text)
+			var invalid *InvalidUTF8Error
+			if errors.As(err, &invalid) != (test.wantErr >= 0) {
+				t.Fatalf("got error %v, want error at %d", err, test.wantErr)
+			}
+			if invalid != nil && invalid.Offset != test.wantErr {
+				t.Errorf("got offset %d, want %d", invalid.Offset, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if test.wantErr < 0 && (!utf8.ValidString(sanitizer.Redact(test.text)) || sanitizer.Redact(test.text) != got) {
+				t.Errorf("Redact should match RedactContext, got %q", sanitizer.Redact(test.text))
+			}
+		})
+	}
+}
+
+func TestMaxMatchLength(t *testing.T) {
+	c := DefaultConfig()
+	tests := []struct {
+		name    string
+		matcher WordMatcher
+		want    int
+	}{
+		{"word with leet speak and obfuscation", WordMatcher{Word: "ass"}, (3 + 2*3) * utf8.UTFMax},
+		{"acronym", WordMatcher{Word: "wtf", Kind: KindAcronym}, 5 * utf8.UTFMax},
+		{"bounded regex", WordMatcher{Regex: "f(u|oo){1,2}ck"}, 7 * utf8.UTFMax},
+		{"unbounded regex", WordMatcher{Regex: "f[u]+ck"}, 0},
+		{"regex with assertions", WordMatcher{Regex: `\bass\b`}, 0},
+		{"regex matching an empty string", WordMatcher{Regex: "(ass)?"}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			re, err := c.compileMatcher(test.matcher)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := maxMatchLength(re); got != test.want {
+				t.Errorf("got %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindAll_Chunks(t *testing.T) {
+	c := DefaultConfig()
+	matchers := c.initializeMatchers([]WordMatcher{{Word: "fuck"}, {Word: "ass"}, {Regex: "f[u]+ck"}})
+	for _, m := range matchers {
+		for _, shift := range []int{-40, -20, -7, -3, -1, 0, 1, 2, 5} {
+			message := strings.Repeat("é", (scanChunkSize+shift)/2) + "f.u.c.k a$$ fuuuck " + strings.Repeat("ß", scanChunkSize) + "ass"
+			var got [][]int
+			if err := findAll(context.Background(), m, message, func(start, end int) bool {
+				got = append(got, []int{start, end})
+				return true
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := m.Matcher.FindAllStringIndex(message, -1); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s shifted by %d: got %v, want %v", m.Matcher, shift, got, want)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	message := "shit " + strings.Repeat("x", 4*scanChunkSize) + " shit"
+	var got int
+	err := findAll(ctx, matchers[0], "fuck "+message, func(start, end int) bool {
+		got++
+		cancel()
+		return true
+	})
+	if !errors.Is(err, context.Canceled) || got != 1 {
+		t.Errorf("got %d matches and %v, want the context to be checked between chunks", got, err)
+	}
+	if _, err := ListContext(ctx, message); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
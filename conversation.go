@@ -0,0 +1,80 @@
+package goclean
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultMaxFragmentLength = 2
+	defaultMaxMessages       = 8
+)
+
+// ConversationOptions limits how words are spelled across messages by ListConversation.
+type ConversationOptions struct {
+	// MaxFragmentLength is the maximum length in runes, without surrounding spaces, of a message that is joined
+	// with its neighbours. Longer messages are matched on their own. Defaults to 2.
+	MaxFragmentLength int
+	// MaxMessages is the maximum number of consecutive messages a word is spelled across. Defaults to 8.
+	MaxMessages int
+}
+
+// ConversationConcern is a concern detected in a sequence of messages.
+type ConversationConcern struct {
+	DetectedConcern
+	// StartMessage and EndMessage are the indexes of the first and the last message of the concern.
+	// StartIndex is a byte offset in the first message and EndIndex in the last one, MatchedText is the joined text.
+	StartMessage, EndMessage int
+}
+
+// ListConversation detects profanities in a sequence of messages, or lines, including words spelled across them
+// ("f", "u", "c", "k"). Consecutive short messages (fragments) are joined without separators and concerns spanning
+// at most MaxMessages of them are reported, wherever they start in the run of fragments. Concerns within a single
+// message are detected like by List.
+func (gc *ProfanitySanitizer) ListConversation(messages []string, opts ConversationOptions) []ConversationConcern {
+	if opts.MaxFragmentLength <= 0 {
+		opts.MaxFragmentLength = defaultMaxFragmentLength
+	}
+	if opts.MaxMessages <= 0 {
+		opts.MaxMessages = defaultMaxMessages
+	}
+	m := newMappedText(&gc.config)
+	defer m.release()
+	// starts are the offsets of the messages in their concatenation, the sources of the mapped text
+	starts := make([]int, len(messages))
+	offset := 0
+	for i, message := range messages {
+		starts[i] = offset
+		trimmed := strings.TrimSpace(message)
+		if utf8.RuneCountInString(trimmed) > opts.MaxFragmentLength {
+			m.appendSeparator()
+			m.appendSource(message, offset)
+			m.appendSeparator()
+		} else {
+			m.appendSource(trimmed, offset+strings.Index(message, trimmed))
+		}
+		offset += len(message)
+	}
+
+	concerns := m.list(gc)
+	result := make([]ConversationConcern, 0, len(concerns))
+	for _, concern := range concerns {
+		start := sort.Search(len(starts), func(i int) bool { return starts[i] > int(concern.StartIndex) }) - 1
+		end := sort.Search(len(starts), func(i int) bool { return starts[i] >= int(concern.EndIndex) }) - 1
+		if end-start+1 > opts.MaxMessages {
+			continue
+		}
+		concern.StartIndex -= int32(starts[start])
+		concern.EndIndex -= int32(starts[end])
+		result = append(result, ConversationConcern{DetectedConcern: concern, StartMessage: start, EndMessage: end})
+	}
+	return result
+}
+
+// ListConversation detects profanities in a sequence of messages, including words spelled across them.
+//
+// Uses the default ProfanitySanitizer
+func ListConversation(messages []string, opts ConversationOptions) []ConversationConcern {
+	return gc.ListConversation(messages, opts)
+}
@@ -0,0 +1,49 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_ListConversation(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		opts     ConversationOptions
+		want     []ConversationConcern
+	}{
+		{"clean", []string{"hello", "there"}, ConversationOptions{}, []ConversationConcern{}},
+		{"vertical", []string{"hello", "f", "u", "c", "k", "bye"}, ConversationOptions{}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{MatchedText: "fuck", StartIndex: 0, EndIndex: 1}, StartMessage: 1, EndMessage: 4},
+		}},
+		{"fragments with spaces", []string{"sh", " it "}, ConversationOptions{}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{Word: "shit", MatchedText: "shit", StartIndex: 0, EndIndex: 3}, StartMessage: 0, EndMessage: 1},
+		}},
+		{"empty messages", []string{"s", "", "h", "i", "t"}, ConversationOptions{}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{Word: "shit", MatchedText: "shit", StartIndex: 0, EndIndex: 1}, StartMessage: 0, EndMessage: 4},
+		}},
+		{"within a message", []string{"ok", "what the fuck"}, ConversationOptions{}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{MatchedText: "fuck", StartIndex: 9, EndIndex: 13}, StartMessage: 1, EndMessage: 1},
+		}},
+		{"long messages separate fragments", []string{"f", "u", "hello there", "c", "k"}, ConversationOptions{}, []ConversationConcern{}},
+		{"longer fragments", []string{"fuc", "k"}, ConversationOptions{MaxFragmentLength: 3}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{MatchedText: "fuck", StartIndex: 0, EndIndex: 1}, StartMessage: 0, EndMessage: 1},
+		}},
+		{"fragment length", []string{"fuc", "k"}, ConversationOptions{}, []ConversationConcern{}},
+		{"message limit", []string{"f", "u", "c", "k"}, ConversationOptions{MaxMessages: 3}, []ConversationConcern{}},
+		{"across the message limit", []string{"a", "b", "c", "d", "e", "f", "s", "h", "i", "t"}, ConversationOptions{}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{Word: "shit", MatchedText: "shit", StartIndex: 0, EndIndex: 1}, StartMessage: 6, EndMessage: 9},
+		}},
+		{"at the message limit", []string{"x", "f", "u", "c", "k"}, ConversationOptions{MaxMessages: 4}, []ConversationConcern{
+			{DetectedConcern: DetectedConcern{MatchedText: "fuck", StartIndex: 0, EndIndex: 1}, StartMessage: 1, EndMessage: 4},
+		}},
+		{"false positives", []string{"b", "a", "ss"}, ConversationOptions{}, []ConversationConcern{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ListConversation(test.messages, test.opts); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package goclean
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed wordlists/english.txt
+var englishWords string
+
+// EnglishWords returns the bundled list of common English words, one word per line.
+func EnglishWords() io.Reader {
+	return strings.NewReader(englishWords)
+}
+
+// FalsePositiveCandidate is a clean word that is detected as profane.
+type FalsePositiveCandidate struct {
+	Word    string
+	Matches []CandidateMatch
+}
+
+// CandidateMatch is a concern detected in a FalsePositiveCandidate.
+type CandidateMatch struct {
+	Concern DetectedConcern
+	// Matcher is the entry of the dictionary responsible for the concern, or nil if it was not found.
+	Matcher *WordMatcher
+}
+
+// DiscoverFalsePositives checks a list of legitimate words (one word or phrase per line, lines starting with "#"
+// are ignored) and returns every word that is detected as profane together with the responsible matchers.
+//
+// Concerns reported by FalseNegatives are skipped, as false positives do not suppress them.
+func (gc *ProfanitySanitizer) DiscoverFalsePositives(words io.Reader) ([]FalsePositiveCandidate, error) {
+	var candidates []FalsePositiveCandidate
+	scanner := bufio.NewScanner(words)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		candidate := FalsePositiveCandidate{Word: word}
+		for _, concern := range gc.List(word) {
+			if gc.isFalseNegative(concern) {
+				continue
+			}
+			match := CandidateMatch{Concern: concern}
+			if matcher, ok := matcherFor(concern, gc.config.Profanities, gc.config.Emoji); ok {
+				match.Matcher = &matcher
+			}
+			candidate.Matches = append(candidate.Matches, match)
+		}
+		if len(candidate.Matches) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, scanner.Err()
+}
+
+// isFalseNegative reports whether the concern was reported by an entry of FalseNegatives.
+func (gc *ProfanitySanitizer) isFalseNegative(concern DetectedConcern) bool {
+	_, ok := matcherFor(concern, gc.config.FalseNegatives)
+	return ok
+}
+
+// matcherFor returns the first matcher of the dictionaries that could have reported the concern.
+func matcherFor(concern DetectedConcern, dictionaries ...[]WordMatcher) (WordMatcher, bool) {
+	for _, matchers := range dictionaries {
+		for _, m := range matchers {
+			if m.Matcher == nil || m.Word != concern.Word || m.Level != concern.Level || m.Category != concern.Category {
+				continue
+			}
+			if loc := m.Matcher.FindStringIndex(concern.MatchedText); loc != nil && loc[0] == 0 && loc[1] == len(concern.MatchedText) {
+				return m, true
+			}
+		}
+	}
+	return WordMatcher{}, false
+}
+
+// SuggestFalsePositives returns FalsePositives entries that suppress the candidates, sorted and without duplicates.
+func SuggestFalsePositives(candidates []FalsePositiveCandidate) []string {
+	seen := make(map[string]bool)
+	suggestions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestion := regexp.QuoteMeta(strings.ToLower(candidate.Word))
+		if !seen[suggestion] {
+			seen[suggestion] = true
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	sort.Strings(suggestions)
+	return suggestions
+}
+
+// DiscoverFalsePositives checks a list of legitimate words and returns every word that is detected as profane.
+//
+// Uses the default ProfanitySanitizer
+func DiscoverFalsePositives(words io.Reader) ([]FalsePositiveCandidate, error) {
+	return gc.DiscoverFalsePositives(words)
+}
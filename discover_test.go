@@ -0,0 +1,51 @@
+package goclean
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProfanitySanitizer_DiscoverFalsePositives(t *testing.T) {
+	sanitizer := NewProfanitySanitizer(&Config{
+		DetectObfuscated: true,
+		Profanities:      []WordMatcher{{Word: "ass", Level: 2}, {Regex: "cr[a]+p", Word: "crap"}},
+		FalsePositives:   []string{"bass"},
+		FalseNegatives:   []WordMatcher{{Word: "dumbass"}},
+	})
+	words := strings.NewReader("# clean words\nhello\nbass\nclassic\n\nskyscraper\nc.lass\ndumbass\ndumbasses\n")
+	candidates, err := sanitizer.DiscoverFalsePositives(words)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, candidate := range candidates {
+		if len(candidate.Matches) != 1 || candidate.Matches[0].Matcher == nil {
+			t.Fatalf("%s: got matches %+v", candidate.Word, candidate.Matches)
+		}
+		match := candidate.Matches[0]
+		got = append(got, candidate.Word+":"+match.Matcher.Word+":"+match.Concern.MatchedText)
+	}
+	want := []string{"classic:ass:ass", "skyscraper:crap:crap", "c.lass:ass:ass"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	suggestions := SuggestFalsePositives(candidates)
+	if want := []string{`c\.lass`, "classic", "skyscraper"}; !reflect.DeepEqual(suggestions, want) {
+		t.Errorf("got %v, want %v", suggestions, want)
+	}
+}
+
+func TestDiscoverFalsePositives_EnglishWords(t *testing.T) {
+	candidates, err := DiscoverFalsePositives(EnglishWords())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, candidate := range candidates {
+		found[candidate.Word] = true
+	}
+	if !found["skyscraper"] || found["bass"] || found["hello"] {
+		t.Errorf("got %v", found)
+	}
+}
@@ -0,0 +1,215 @@
+// Package eval measures the detection quality of a go-clean configuration against a labelled dataset.
+//
+// A dataset is a JSONL file with one example per line:
+//
+//	{"text": "what the fuck", "spans": [{"start": 9, "end": 13, "word": "fuck", "category": "sexual"}]}
+//	{"text": "a bass guitar"}
+//
+// Spans are byte offsets of the profanities in the text. An example is profane if it has spans, unless
+// "profane" is set explicitly.
+package eval
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+// Span is an expected profanity in an Example.
+type Span struct {
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Word     string `json:"word,omitempty"`
+	Category string `json:"category,omitempty"`
+}
+
+// Example is a labelled text of a dataset.
+type Example struct {
+	Text    string `json:"text"`
+	Spans   []Span `json:"spans,omitempty"`
+	Profane *bool  `json:"profane,omitempty"`
+}
+
+// IsProfane returns whether the example is expected to be detected as profane.
+func (e Example) IsProfane() bool {
+	if e.Profane != nil {
+		return *e.Profane
+	}
+	return len(e.Spans) > 0
+}
+
+// ReadDataset reads a JSONL dataset. Empty lines are ignored.
+func ReadDataset(r io.Reader) ([]Example, error) {
+	var dataset []Example
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var example Example
+		if err := json.Unmarshal(scanner.Bytes(), &example); err != nil {
+			return nil, fmt.Errorf("eval: line %d: %w", line, err)
+		}
+		for _, span := range example.Spans {
+			if span.Start < 0 || span.End > len(example.Text) || span.Start >= span.End {
+				return nil, fmt.Errorf("eval: line %d: span [%d, %d) is out of bounds", line, span.Start, span.End)
+			}
+		}
+		dataset = append(dataset, example)
+	}
+	return dataset, scanner.Err()
+}
+
+// Counts is a confusion matrix.
+type Counts struct {
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	TrueNegatives  int
+}
+
+// Precision is the share of detections that are correct, it's 1 if nothing was detected.
+func (c Counts) Precision() float64 {
+	if c.TruePositives+c.FalsePositives == 0 {
+		return 1
+	}
+	return float64(c.TruePositives) / float64(c.TruePositives+c.FalsePositives)
+}
+
+// Recall is the share of expected profanities that were detected, it's 1 if nothing was expected.
+func (c Counts) Recall() float64 {
+	if c.TruePositives+c.FalseNegatives == 0 {
+		return 1
+	}
+	return float64(c.TruePositives) / float64(c.TruePositives+c.FalseNegatives)
+}
+
+// F1 is the harmonic mean of precision and recall.
+func (c Counts) F1() float64 {
+	p, r := c.Precision(), c.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// Result is the outcome of a single example.
+type Result struct {
+	Example Example
+	// Concerns are the detected concerns, with byte offsets in the text of the example like the spans.
+	Concerns []goclean.DetectedConcern
+	// Missed are the expected spans that were not detected.
+	Missed []Span
+	// Unexpected are the concerns that do not overlap any expected span.
+	Unexpected []goclean.DetectedConcern
+}
+
+// Correct reports whether all spans were detected without any unexpected concern.
+func (r Result) Correct() bool {
+	return len(r.Missed) == 0 && len(r.Unexpected) == 0 && (len(r.Concerns) > 0) == r.Example.IsProfane()
+}
+
+// Report contains the evaluation of a configuration.
+type Report struct {
+	// Messages counts whole examples as profane or clean.
+	Messages Counts
+	// Spans counts expected spans and detected concerns, a concern is correct if it overlaps an expected span.
+	Spans Counts
+	// Categories are span counts by category, uncategorized spans are counted under "".
+	Categories map[string]Counts
+	// Words are span counts by the expected word, or the detected word for unexpected concerns.
+	Words   map[string]Counts
+	Results []Result
+}
+
+// Evaluate runs the sanitizer on every example of the dataset.
+func Evaluate(sanitizer *goclean.ProfanitySanitizer, dataset []Example) *Report {
+	report := &Report{
+		Categories: make(map[string]Counts),
+		Words:      make(map[string]Counts),
+	}
+	for _, example := range dataset {
+		// the spans are offsets in the text, not in the sanitized text the offsets of List refer to
+		concerns := sanitizer.ListWithOptions(example.Text, goclean.ListOptions{SourceOffsets: true})
+		result := Result{Example: example, Concerns: concerns}
+		profane := example.IsProfane()
+		switch detected := len(result.Concerns) > 0; {
+		case profane && detected:
+			report.Messages.TruePositives++
+		case profane:
+			report.Messages.FalseNegatives++
+		case detected:
+			report.Messages.FalsePositives++
+		default:
+			report.Messages.TrueNegatives++
+		}
+
+		for _, span := range example.Spans {
+			word := spanWord(example, span)
+			if overlapsConcern(span, result.Concerns) {
+				report.Spans.TruePositives++
+				report.count(span.Category, word, func(c *Counts) { c.TruePositives++ })
+			} else {
+				result.Missed = append(result.Missed, span)
+				report.Spans.FalseNegatives++
+				report.count(span.Category, word, func(c *Counts) { c.FalseNegatives++ })
+			}
+		}
+		for _, concern := range result.Concerns {
+			if overlapsSpan(concern, example.Spans) {
+				continue
+			}
+			result.Unexpected = append(result.Unexpected, concern)
+			report.Spans.FalsePositives++
+			report.count(concern.Category, concernWord(concern), func(c *Counts) { c.FalsePositives++ })
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+func (r *Report) count(category, word string, update func(c *Counts)) {
+	counts := r.Categories[category]
+	update(&counts)
+	r.Categories[category] = counts
+	counts = r.Words[word]
+	update(&counts)
+	r.Words[word] = counts
+}
+
+func spanWord(example Example, span Span) string {
+	if span.Word != "" {
+		return strings.ToLower(span.Word)
+	}
+	return strings.ToLower(example.Text[span.Start:span.End])
+}
+
+func concernWord(concern goclean.DetectedConcern) string {
+	if concern.Word != "" {
+		return strings.ToLower(concern.Word)
+	}
+	return strings.ToLower(concern.MatchedText)
+}
+
+func overlapsConcern(span Span, concerns []goclean.DetectedConcern) bool {
+	for _, concern := range concerns {
+		if int(concern.StartIndex) < span.End && span.Start < int(concern.EndIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlapsSpan(concern goclean.DetectedConcern, spans []Span) bool {
+	for _, span := range spans {
+		if int(concern.StartIndex) < span.End && span.Start < int(concern.EndIndex) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package eval
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	goclean "github.com/martinhrvn/go-clean"
+)
+
+func readTestDataset(t *testing.T) []Example {
+	t.Helper()
+	file, err := os.Open("testdata/dataset.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	dataset, err := ReadDataset(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dataset
+}
+
+func newSanitizer(profanities []goclean.WordMatcher, falsePositives []string) *goclean.ProfanitySanitizer {
+	sanitizer := goclean.NewProfanitySanitizer(&goclean.Config{
+		ReplacementCharacter: "*",
+		Profanities:          profanities,
+		FalsePositives:       falsePositives,
+	})
+	return &sanitizer
+}
+
+func TestReadDataset(t *testing.T) {
+	dataset := readTestDataset(t)
+	if len(dataset) != 6 {
+		t.Fatalf("got %d examples, want 6", len(dataset))
+	}
+	if !dataset[0].IsProfane() || dataset[2].IsProfane() || dataset[5].IsProfane() {
+		t.Errorf("got %+v", dataset)
+	}
+	_, err := ReadDataset(strings.NewReader(`{"text": "ass", "spans": [{"start": 0, "end": 4}]}`))
+	if err == nil {
+		t.Errorf("expected error for span out of bounds")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	sanitizer := newSanitizer([]goclean.WordMatcher{
+		{Word: "fuck", Category: "sexual"},
+		{Word: "ass", Category: "insult"},
+	}, nil)
+	report := Evaluate(sanitizer, readTestDataset(t))
+
+	if want := (Counts{TruePositives: 2, FalsePositives: 2, FalseNegatives: 1, TrueNegatives: 1}); report.Messages != want {
+		t.Errorf("messages: got %+v, want %+v", report.Messages, want)
+	}
+	if want := (Counts{TruePositives: 2, FalsePositives: 2, FalseNegatives: 1}); report.Spans != want {
+		t.Errorf("spans: got %+v, want %+v", report.Spans, want)
+	}
+	if got := report.Spans.Precision(); got != 0.5 {
+		t.Errorf("precision: got %f, want 0.5", got)
+	}
+	if got := report.Spans.Recall(); got != 2.0/3 {
+		t.Errorf("recall: got %f, want 0.667", got)
+	}
+	wantCategories := map[string]Counts{
+		"sexual": {TruePositives: 1},
+		"insult": {TruePositives: 1, FalsePositives: 2},
+		"":       {FalseNegatives: 1},
+	}
+	if !reflect.DeepEqual(report.Categories, wantCategories) {
+		t.Errorf("categories: got %+v, want %+v", report.Categories, wantCategories)
+	}
+	wantWords := map[string]Counts{
+		"fuck": {TruePositives: 1},
+		"ass":  {TruePositives: 1, FalsePositives: 2},
+		"crap": {FalseNegatives: 1},
+	}
+	if !reflect.DeepEqual(report.Words, wantWords) {
+		t.Errorf("words: got %+v, want %+v", report.Words, wantWords)
+	}
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"messages  0.500      0.667   0.571  2   2   1   1", "ass   0.333  1.000  0.500  1  2  0  0"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report should contain %q, got\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestEvaluate_NonASCII(t *testing.T) {
+	sanitizer := newSanitizer([]goclean.WordMatcher{{Word: "shit"}}, nil)
+	dataset := []Example{{Text: "ééééé shit", Spans: []Span{{Start: 11, End: 15}}}}
+	report := Evaluate(sanitizer, dataset)
+	if want := (Counts{TruePositives: 1}); report.Spans != want {
+		t.Errorf("spans: got %+v, want %+v", report.Spans, want)
+	}
+	if !report.Results[0].Correct() {
+		t.Errorf("got %+v", report.Results[0])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	dataset := readTestDataset(t)
+	base := Evaluate(newSanitizer([]goclean.WordMatcher{{Word: "fuck"}, {Word: "ass"}}, nil), dataset)
+	head := Evaluate(newSanitizer([]goclean.WordMatcher{{Word: "fuck"}, {Word: "ass"}, {Word: "crap"}}, []string{"bass", "lass"}), dataset)
+	diff, err := Compare(base, head)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{2, 3, 4}; !reflect.DeepEqual(diff.Fixed, want) {
+		t.Errorf("fixed: got %v, want %v", diff.Fixed, want)
+	}
+	if len(diff.Regressed) != 0 {
+		t.Errorf("regressed: got %v", diff.Regressed)
+	}
+	var buf bytes.Buffer
+	if err := diff.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"spans f1            0.571  1.000  +0.429", "fixed (3):\n  example 3: \"a bass guitar\""} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("diff should contain %q, got\n%s", want, buf.String())
+		}
+	}
+	if _, err := Compare(base, Evaluate(newSanitizer(nil, nil), dataset[:2])); err == nil {
+		t.Errorf("expected error for different datasets")
+	}
+}
@@ -0,0 +1,115 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// Write writes the report as text: message and span metrics, followed by the metrics of every category and word.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tprecision\trecall\tf1\ttp\tfp\tfn\ttn")
+	writeCounts(tw, "messages", r.Messages)
+	writeCounts(tw, "spans", r.Spans)
+	if len(r.Categories) > 0 {
+		fmt.Fprintln(tw, "\ncategory\t\t\t\t\t\t\t")
+		for _, category := range sortedKeys(r.Categories) {
+			name := category
+			if name == "" {
+				name = "(none)"
+			}
+			writeCounts(tw, name, r.Categories[category])
+		}
+	}
+	if len(r.Words) > 0 {
+		fmt.Fprintln(tw, "\nword\t\t\t\t\t\t\t")
+		for _, word := range sortedKeys(r.Words) {
+			writeCounts(tw, word, r.Words[word])
+		}
+	}
+	return tw.Flush()
+}
+
+func writeCounts(w io.Writer, name string, c Counts) {
+	fmt.Fprintf(w, "%s\t%.3f\t%.3f\t%.3f\t%d\t%d\t%d\t%d\n",
+		name, c.Precision(), c.Recall(), c.F1(), c.TruePositives, c.FalsePositives, c.FalseNegatives, c.TrueNegatives)
+}
+
+func sortedKeys(m map[string]Counts) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Diff compares the evaluations of two configurations on the same dataset.
+type Diff struct {
+	Base *Report
+	Head *Report
+	// Fixed are the indexes of examples that are correct with head, but not with base.
+	Fixed []int
+	// Regressed are the indexes of examples that are correct with base, but not with head.
+	Regressed []int
+}
+
+// Compare compares the reports of two configurations evaluated on the same dataset.
+func Compare(base, head *Report) (*Diff, error) {
+	if len(base.Results) != len(head.Results) {
+		return nil, errors.New("eval: reports are not evaluated on the same dataset")
+	}
+	diff := &Diff{Base: base, Head: head}
+	for i := range base.Results {
+		if base.Results[i].Example.Text != head.Results[i].Example.Text {
+			return nil, errors.New("eval: reports are not evaluated on the same dataset")
+		}
+		switch baseCorrect, headCorrect := base.Results[i].Correct(), head.Results[i].Correct(); {
+		case headCorrect && !baseCorrect:
+			diff.Fixed = append(diff.Fixed, i)
+		case baseCorrect && !headCorrect:
+			diff.Regressed = append(diff.Regressed, i)
+		}
+	}
+	return diff, nil
+}
+
+// Write writes the metrics of both configurations with their change, followed by the fixed and regressed examples.
+func (d *Diff) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tbase\thead\tdelta")
+	for _, metric := range []struct {
+		name       string
+		base, head Counts
+	}{
+		{"messages", d.Base.Messages, d.Head.Messages},
+		{"spans", d.Base.Spans, d.Head.Spans},
+	} {
+		writeDelta(tw, metric.name+" precision", metric.base.Precision(), metric.head.Precision())
+		writeDelta(tw, metric.name+" recall", metric.base.Recall(), metric.head.Recall())
+		writeDelta(tw, metric.name+" f1", metric.base.F1(), metric.head.F1())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	writeExamples(w, "fixed", d.Fixed, d.Head)
+	writeExamples(w, "regressed", d.Regressed, d.Head)
+	return nil
+}
+
+func writeDelta(w io.Writer, name string, base, head float64) {
+	fmt.Fprintf(w, "%s\t%.3f\t%.3f\t%+.3f\n", name, base, head, head-base)
+}
+
+func writeExamples(w io.Writer, name string, indexes []int, r *Report) {
+	if len(indexes) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (%d):\n", name, len(indexes))
+	for _, i := range indexes {
+		fmt.Fprintf(w, "  example %d: %q\n", i+1, r.Results[i].Example.Text)
+	}
+}
@@ -0,0 +1,224 @@
+package goclean
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format is the format of a configuration file.
+type Format int
+
+const (
+	// FormatJSON is the config.json format.
+	FormatJSON Format = iota
+	// FormatYAML is a YAML document with the same keys as the JSON format.
+	FormatYAML
+	// FormatTOML is a TOML document with the same keys as the JSON format.
+	FormatTOML
+	// FormatText is a plain word list with one entry per line.
+	FormatText
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	case FormatTOML:
+		return "toml"
+	case FormatText:
+		return "text"
+	}
+	return "unknown"
+}
+
+var (
+	yamlKeyLine  = regexp.MustCompile(`^[A-Za-z_][\w-]*:(\s|$)`)
+	tomlKeyLine  = regexp.MustCompile(`^[A-Za-z_][\w-]*\s*=`)
+	tomlTableRow = regexp.MustCompile(`^\[\[?[A-Za-z_][\w.-]*\]\]?\s*(#.*)?$`)
+)
+
+// DetectFormat detects the format of a configuration file from the extension of its name,
+// or from its content if the extension is not known.
+func DetectFormat(name string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	case ".txt", ".text", ".lst":
+		return FormatText
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return FormatJSON
+		case line == "---" || strings.HasPrefix(line, "- ") || yamlKeyLine.MatchString(line):
+			return FormatYAML
+		case tomlKeyLine.MatchString(line) || tomlTableRow.MatchString(line):
+			return FormatTOML
+		}
+		return FormatText
+	}
+	return FormatText
+}
+
+// LoadConfig reads the configuration file at path, detecting its format with DetectFormat.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, DetectFormat(path, data))
+}
+
+// ParseConfig parses a configuration in the given format.
+//
+// Options missing from the configuration are set to their documented defaults.
+func ParseConfig(data []byte, format Format) (*Config, error) {
+	var document interface{}
+	var err error
+	switch format {
+	case FormatJSON:
+		return decodeConfig(data)
+	case FormatYAML:
+		document, err = parseYAML(data)
+	case FormatTOML:
+		document, err = parseTOML(data)
+	case FormatText:
+		document, err = parseText(data)
+	default:
+		return nil, fmt.Errorf("goclean: unknown config format %d", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		document = map[string]interface{}{}
+	}
+	if _, ok := document.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("goclean: %s config must be a mapping", format)
+	}
+	data, err = json.Marshal(withScalarTypes(document, reflect.TypeOf(Config{})))
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfig(data)
+}
+
+// newConfig returns a configuration with the documented defaults of the options.
+func newConfig() *Config {
+	return &Config{
+		DetectLeetSpeak:      true,
+		DetectObfuscated:     true,
+		ReplacementCharacter: "*",
+		ObfuscationLength:    3,
+	}
+}
+
+func decodeConfig(data []byte) (*Config, error) {
+	c := newConfig()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(c); err != nil {
+		return nil, fmt.Errorf("goclean: invalid config: %w", err)
+	}
+	return c, nil
+}
+
+// plainScalar is an unquoted bool or number. It keeps its text for options that are strings, like "word: 69".
+type plainScalar struct {
+	text  string
+	value interface{}
+}
+
+// parseScalar converts an unquoted scalar to a plainScalar, nil or string.
+func parseScalar(value string) interface{} {
+	switch value {
+	case "true":
+		return plainScalar{value, true}
+	case "false":
+		return plainScalar{value, false}
+	case "null", "~":
+		return nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return plainScalar{value, i}
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return plainScalar{value, f}
+	}
+	return value
+}
+
+var (
+	wordMatcherType = reflect.TypeOf(WordMatcher{})
+	anyType         = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// withScalarTypes replaces the plain scalars of a parsed document with their text where the option of type t
+// is a string, or a WordMatcher that is decoded from a string, and with their value everywhere else.
+func withScalarTypes(document interface{}, t reflect.Type) interface{} {
+	switch v := document.(type) {
+	case plainScalar:
+		if t.Kind() == reflect.String || t == wordMatcherType {
+			return v.text
+		}
+		return v.value
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = withScalarTypes(value, fieldType(t, key))
+		}
+	case []interface{}:
+		elem := anyType
+		if t.Kind() == reflect.Slice {
+			elem = t.Elem()
+		}
+		for i, value := range v {
+			v[i] = withScalarTypes(value, elem)
+		}
+	}
+	return document
+}
+
+// fieldType returns the type of the option named key in a struct or map of type t.
+func fieldType(t reflect.Type, key string) reflect.Type {
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem()
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			// encoding/json matches the keys case-insensitively
+			if name != "-" && strings.EqualFold(name, key) {
+				return t.Field(i).Type
+			}
+		}
+	}
+	return anyType
+}
+
+// ConfigSyntaxError is returned when a YAML, TOML or text configuration can not be parsed.
+type ConfigSyntaxError struct {
+	Format Format
+	Line   int
+	Msg    string
+}
+
+func (e *ConfigSyntaxError) Error() string {
+	return fmt.Sprintf("goclean: invalid %s config on line %d: %s", e.Format, e.Line, e.Msg)
+}
@@ -0,0 +1,181 @@
+package goclean
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want Format
+	}{
+		{"json extension", "words.json", "", FormatJSON},
+		{"yaml extension", "words.yml", "", FormatYAML},
+		{"toml extension", "words.TOML", "", FormatTOML},
+		{"text extension", "words.txt", "", FormatText},
+		{"json content", "words", "\n  {\"profanities\": []}", FormatJSON},
+		{"yaml content", "words", "# comment\nprofanities:\n  - word: ass", FormatYAML},
+		{"yaml sequence content", "words", "- ass", FormatYAML},
+		{"toml content", "words", "# comment\n[[profanities]]\nword = \"ass\"", FormatTOML},
+		{"toml key content", "words", "detectLeetSpeak = true", FormatTOML},
+		{"text content", "words", "# comment\nass level=2\nshit", FormatText},
+		{"empty content", "words", "", FormatText},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DetectFormat(test.file, []byte(test.data)); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	want := &Config{
+		DetectLeetSpeak:      true,
+		DetectObfuscated:     false,
+		ReplacementCharacter: "#",
+		ObfuscationLength:    2,
+		Profanities: []WordMatcher{
+			{Word: "ass", Level: 2, Category: "insult"},
+			{Word: "fuck", Regex: "f[u]+ck"},
+			{Word: "shit", Level: 1},
+		},
+		FalsePositives: []string{"bass", "pass"},
+		FalseNegatives: []WordMatcher{{Word: "dumbass"}},
+	}
+	for _, file := range []string{"testdata/config.json", "testdata/config.yaml", "testdata/config.toml", "testdata/config.txt"} {
+		t.Run(file, func(t *testing.T) {
+			got, err := LoadConfig(file)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+			sanitizer := NewProfanitySanitizer(got)
+			if redacted := sanitizer.Redact("a$$ bass dumbass fuuuck"); redacted != "### bass ####### ######" {
+				t.Errorf("got %s", redacted)
+			}
+			if concerns := sanitizer.List("ass"); len(concerns) != 1 || concerns[0].Category != "insult" {
+				t.Errorf("got %v, want category insult", concerns)
+			}
+		})
+	}
+}
+
+func TestParseConfig_Defaults(t *testing.T) {
+	got, err := ParseConfig([]byte("ass\nshit\n"), FormatText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.DetectLeetSpeak || !got.DetectObfuscated || got.ReplacementCharacter != "*" || got.ObfuscationLength != 3 {
+		t.Errorf("missing options should be set to their defaults, got %+v", got)
+	}
+}
+
+func TestParseConfig_TextEmoji(t *testing.T) {
+	got, err := ParseConfig([]byte("ass\n@emoji\n🖕 level=2 category=gesture\n/8=+D/ word=8==D\n"), FormatText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []WordMatcher{{Word: "🖕", Level: 2, Category: "gesture"}, {Word: "8==D", Regex: "8=+D"}}
+	if !reflect.DeepEqual(got.Emoji, want) {
+		t.Errorf("got %+v, want %+v", got.Emoji, want)
+	}
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		format   Format
+		wantLine int
+	}{
+		{"yaml bad indentation", "profanities:\n  - word: ass\n      level: 2", FormatYAML, 3},
+		{"yaml duplicate key", "detectLeetSpeak: true\ndetectLeetSpeak: false", FormatYAML, 2},
+		{"yaml unterminated flow", "falsePositives: [bass, pass", FormatYAML, 1},
+		{"yaml block scalar", "falsePositives: |\n  bass", FormatYAML, 1},
+		{"toml missing equals", "detectLeetSpeak true", FormatTOML, 1},
+		{"toml unterminated array", "falsePositives = [\n\"bass\",\n", FormatTOML, 3},
+		{"toml duplicate key", "[[profanities]]\nword = \"a\"\nword = \"b\"", FormatTOML, 3},
+		{"text unknown section", "ass\n@unknown", FormatText, 2},
+		{"text invalid annotation", "ass level", FormatText, 1},
+		{"text annotated false positive", "@falsePositives\nbass level=2", FormatText, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(test.data), test.format)
+			var syntaxErr *ConfigSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("got %v, want ConfigSyntaxError", err)
+			}
+			if syntaxErr.Line != test.wantLine {
+				t.Errorf("got line %d, want %d: %v", syntaxErr.Line, test.wantLine, err)
+			}
+		})
+	}
+	if _, err := ParseConfig([]byte("detectLeetSpek: true"), FormatYAML); err == nil {
+		t.Errorf("expected error for unknown key")
+	}
+	if _, err := ParseConfig([]byte("invalidUTF8: ignore"), FormatYAML); err == nil {
+		t.Errorf("expected error for unknown invalid UTF-8 policy")
+	}
+	if _, err := ParseConfig([]byte("wtf kind=initialism"), FormatText); err == nil {
+		t.Errorf("expected error for unknown matcher kind")
+	}
+}
+
+func TestParseConfig_Scalars(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+		want   []WordMatcher
+	}{
+		{"yaml number as word", "profanities:\n  - word: 69\n    level: 2", FormatYAML, []WordMatcher{{Word: "69", Level: 2}}},
+		{"yaml number keeps its text", "profanities: [007, 1.50, true]", FormatYAML, []WordMatcher{{Word: "007"}, {Word: "1.50"}, {Word: "true"}}},
+		{"yaml escapes", `profanities: ["x\u00e9", "\x41\U0001F595", "\ud83d\udd95"]`, FormatYAML, []WordMatcher{{Word: "xé"}, {Word: "A🖕"}, {Word: "🖕"}}},
+		{"yaml unknown escapes", `profanities: [{regex: "f\W*ck"}]`, FormatYAML, []WordMatcher{{Regex: `f\W*ck`}}},
+		{"toml escapes", "[[profanities]]\nword = \"x\\u00e9\"", FormatTOML, []WordMatcher{{Word: "xé"}}},
+		{"text number as word", "69 level=2 category=420", FormatText, []WordMatcher{{Word: "69", Level: 2, Category: "420"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseConfig([]byte(test.data), test.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Profanities, test.want) {
+				t.Errorf("got %+v, want %+v", got.Profanities, test.want)
+			}
+		})
+	}
+	if _, err := ParseConfig([]byte(`falsePositives: ["\u00zz"]`), FormatYAML); err == nil {
+		t.Errorf("expected error for invalid escape")
+	}
+}
+
+func TestParseConfig_JSONDefaults(t *testing.T) {
+	got, err := ParseConfig([]byte(`{"profanities": ["ass"]}`), FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := newConfig()
+	want.Profanities = []WordMatcher{{Word: "ass"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	loaded, err := LoadConfig("config.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, DefaultConfig()) {
+		t.Errorf("LoadConfig and DefaultConfig differ for config.json")
+	}
+}
@@ -0,0 +1,133 @@
+package goclean
+
+import (
+	"errors"
+	"strings"
+)
+
+// parseText parses a plain word list with one entry per line:
+//
+//	# comments start with "#"
+//	@obfuscationLength 2        options are set with "@option value"
+//	shit level=2 category=scatological
+//	/f[u]+ck/ word=fuck         regexes are enclosed in slashes
+//	@falsePositives             "@section" switches to profanities, falsePositives, falseNegatives or emoji
+//	bass
+//
+// Annotations are "key=value" pairs with the keys of the JSON format, values with spaces can be quoted.
+func parseText(data []byte) (interface{}, error) {
+	document := make(map[string]interface{})
+	section := "profanities"
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := stripComment(strings.TrimSpace(raw))
+		if line == "" {
+			continue
+		}
+		lineErr := func(msg string) error {
+			return &ConfigSyntaxError{Format: FormatText, Line: i + 1, Msg: msg}
+		}
+		if strings.HasPrefix(line, "@") {
+			name, value := line[1:], ""
+			if j := strings.IndexAny(name, " \t"); j >= 0 {
+				name, value = name[:j], strings.TrimSpace(name[j:])
+			}
+			switch {
+			case value != "":
+				parsed, err := parseTextValue(value)
+				if err != nil {
+					return nil, lineErr("invalid value of @" + name)
+				}
+				document[name] = parsed
+			case name == "profanities" || name == "falsePositives" || name == "falseNegatives" || name == "emoji":
+				section = name
+			default:
+				return nil, lineErr("unknown section @" + name)
+			}
+			continue
+		}
+		entry, annotations, err := splitTextEntry(line)
+		if err != nil {
+			return nil, lineErr(err.Error())
+		}
+		if section == "falsePositives" {
+			if len(annotations) > 0 {
+				return nil, lineErr("false positives can not be annotated")
+			}
+			document[section] = append(textSection(document, section), strings.Trim(entry, "/"))
+			continue
+		}
+		matcher := map[string]interface{}{}
+		if len(entry) > 1 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/") {
+			matcher["regex"] = entry[1 : len(entry)-1]
+		} else {
+			matcher["word"] = entry
+		}
+		for _, annotation := range annotations {
+			key, value, ok := strings.Cut(annotation, "=")
+			if !ok || key == "" {
+				return nil, lineErr("invalid annotation " + annotation)
+			}
+			parsed, err := parseTextValue(value)
+			if err != nil {
+				return nil, lineErr("invalid annotation " + annotation)
+			}
+			matcher[key] = parsed
+		}
+		document[section] = append(textSection(document, section), matcher)
+	}
+	return document, nil
+}
+
+func parseTextValue(value string) (interface{}, error) {
+	if !strings.HasPrefix(value, `"`) && !strings.HasPrefix(value, "'") {
+		return parseScalar(value), nil
+	}
+	unquoted, n, err := parseQuoted(value)
+	if err == nil && n != len(value) {
+		err = errors.New("unexpected text after quoted value")
+	}
+	return unquoted, err
+}
+
+func textSection(document map[string]interface{}, section string) []interface{} {
+	entries, _ := document[section].([]interface{})
+	return entries
+}
+
+// splitTextEntry splits the line into the entry and its annotations. Regex entries may contain spaces.
+func splitTextEntry(line string) (string, []string, error) {
+	entry, rest := line, ""
+	if strings.HasPrefix(line, "/") {
+		end := strings.LastIndex(line, "/")
+		if end == 0 {
+			return "", nil, errors.New("unterminated regex")
+		}
+		entry, rest = line[:end+1], line[end+1:]
+		if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+			return "", nil, errors.New("expected annotations after regex")
+		}
+	} else if i := strings.IndexAny(line, " \t"); i >= 0 {
+		entry, rest = line[:i], line[i:]
+	}
+	var annotations []string
+	rest = strings.TrimSpace(rest)
+	for rest != "" {
+		end := 0
+		var quote byte
+		for ; end < len(rest); end++ {
+			c := rest[end]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+			} else if c == '"' || c == '\'' {
+				quote = c
+			} else if c == ' ' || c == '\t' {
+				break
+			}
+		}
+		annotations = append(annotations, rest[:end])
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return entry, annotations, nil
+}
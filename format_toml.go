@@ -0,0 +1,267 @@
+package goclean
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the subset of TOML used by configuration files: key/value pairs, tables,
+// arrays of tables, arrays, inline tables, strings, integers, floats, booleans and comments.
+// Dotted keys, multi-line strings and dates are not supported.
+func parseTOML(data []byte) (interface{}, error) {
+	p := &tomlParser{text: string(data)}
+	root := make(map[string]interface{})
+	current := root
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return root, nil
+		}
+		switch {
+		case strings.HasPrefix(p.text[p.pos:], "[["):
+			p.pos += 2
+			name, err := p.parseTableName("]]")
+			if err != nil {
+				return nil, err
+			}
+			tables, ok := root[name].([]interface{})
+			if _, exists := root[name]; exists && !ok {
+				return nil, p.errorf("%q is not an array of tables", name)
+			}
+			current = make(map[string]interface{})
+			root[name] = append(tables, current)
+		case p.text[p.pos] == '[':
+			p.pos++
+			name, err := p.parseTableName("]")
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := root[name]; exists {
+				return nil, p.errorf("duplicate table %q", name)
+			}
+			current = make(map[string]interface{})
+			root[name] = current
+		default:
+			if err := p.parseKeyValue(current); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectLineEnd(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type tomlParser struct {
+	text string
+	pos  int
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(p.text[:p.pos], "\n") + 1
+	return &ConfigSyntaxError{Format: FormatTOML, Line: line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.text)
+}
+
+// skipBlank skips spaces and comments, and also new lines if newlines is true.
+func (p *tomlParser) skipBlank(newlines bool) {
+	for !p.eof() {
+		switch c := p.text[p.pos]; {
+		case c == ' ' || c == '\t':
+			p.pos++
+		case newlines && (c == '\n' || c == '\r'):
+			p.pos++
+		case c == '#':
+			for !p.eof() && p.text[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) expectLineEnd() error {
+	p.skipBlank(false)
+	if p.eof() {
+		return nil
+	}
+	if p.text[p.pos] == '\n' || p.text[p.pos] == '\r' {
+		return nil
+	}
+	return p.errorf("expected end of line, got %q", p.rest())
+}
+
+func (p *tomlParser) rest() string {
+	rest := p.text[p.pos:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+func (p *tomlParser) parseTableName(end string) (string, error) {
+	p.skipBlank(false)
+	name, err := p.parseKey()
+	if err != nil {
+		return "", err
+	}
+	p.skipBlank(false)
+	if !strings.HasPrefix(p.text[p.pos:], end) {
+		return "", p.errorf("expected %q after table name", end)
+	}
+	p.pos += len(end)
+	return name, nil
+}
+
+func (p *tomlParser) parseKey() (string, error) {
+	if p.eof() {
+		return "", p.errorf("expected a key")
+	}
+	if c := p.text[p.pos]; c == '"' || c == '\'' {
+		return p.parseString()
+	}
+	start := p.pos
+	for !p.eof() && isBareKeyChar(p.text[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.errorf("expected a key, got %q", p.rest())
+	}
+	return p.text[start:p.pos], nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseKeyValue(table map[string]interface{}) error {
+	key, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipBlank(false)
+	if p.eof() || p.text[p.pos] != '=' {
+		return p.errorf("expected '=' after key %q", key)
+	}
+	p.pos++
+	p.skipBlank(false)
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	if _, exists := table[key]; exists {
+		return p.errorf("duplicate key %q", key)
+	}
+	table[key] = value
+	return nil
+}
+
+func (p *tomlParser) parseValue() (interface{}, error) {
+	if p.eof() {
+		return nil, p.errorf("expected a value")
+	}
+	switch p.text[p.pos] {
+	case '"', '\'':
+		return p.parseString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	}
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	token := p.text[start:p.pos]
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	number := strings.ReplaceAll(token, "_", "")
+	if i, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, nil
+	}
+	p.pos = start
+	return nil, p.errorf("invalid value %q", token)
+}
+
+func (p *tomlParser) parseString() (string, error) {
+	if strings.HasPrefix(p.text[p.pos:], `"""`) || strings.HasPrefix(p.text[p.pos:], "'''") {
+		return "", p.errorf("multi-line strings are not supported")
+	}
+	if p.text[p.pos] == '\'' {
+		end := strings.IndexAny(p.text[p.pos+1:], "'\n")
+		if end < 0 || p.text[p.pos+1+end] != '\'' {
+			return "", p.errorf("unterminated string")
+		}
+		value := p.text[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+	value, n, err := parseQuoted(p.rest())
+	if err != nil {
+		return "", p.errorf("%v", err)
+	}
+	p.pos += n
+	return value, nil
+}
+
+func (p *tomlParser) parseArray() (interface{}, error) {
+	p.pos++
+	array := make([]interface{}, 0)
+	for {
+		p.skipBlank(true)
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.text[p.pos] == ']' {
+			p.pos++
+			return array, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+		p.skipBlank(true)
+		if !p.eof() && p.text[p.pos] == ',' {
+			p.pos++
+		} else if p.eof() || p.text[p.pos] != ']' {
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (interface{}, error) {
+	p.pos++
+	table := make(map[string]interface{})
+	for {
+		p.skipBlank(false)
+		if p.eof() {
+			return nil, p.errorf("unterminated inline table")
+		}
+		if p.text[p.pos] == '}' {
+			p.pos++
+			return table, nil
+		}
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipBlank(false)
+		if !p.eof() && p.text[p.pos] == ',' {
+			p.pos++
+		} else if p.eof() || p.text[p.pos] != '}' {
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
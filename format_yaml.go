@@ -0,0 +1,389 @@
+package goclean
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// parseYAML parses the subset of YAML used by configuration files: block mappings and sequences,
+// flow mappings and sequences, plain and quoted scalars and comments.
+// Anchors, tags, multiple documents and block scalars are not supported.
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(string(data), "\n") {
+		content := stripComment(strings.TrimRight(raw, " \t\r"))
+		trimmed := strings.TrimLeft(content, " ")
+		if trimmed == "" || len(p.lines) == 0 && trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, &ConfigSyntaxError{Format: FormatYAML, Line: i + 1, Msg: "tabs can not be used for indentation"}
+		}
+		p.lines = append(p.lines, yamlLine{indent: len(content) - len(trimmed), text: trimmed, number: i + 1})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	value, err := p.parseNode(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return value, nil
+}
+
+type yamlLine struct {
+	indent int
+	text   string
+	number int
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos].number
+	} else if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1].number
+	}
+	return &ConfigSyntaxError{Format: FormatYAML, Line: line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	line := p.lines[p.pos]
+	if isSequenceItem(line.text) {
+		return p.parseSequence(indent)
+	}
+	if _, _, ok := splitYAMLKey(line.text); ok {
+		return p.parseMapping(indent)
+	}
+	p.pos++
+	value, err := parseFlowValue(line.text)
+	if err != nil {
+		p.pos--
+		return nil, p.errorf("%v", err)
+	}
+	return value, nil
+}
+
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	sequence := make([]interface{}, 0)
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			var item interface{}
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				var err error
+				if item, err = p.parseNode(p.lines[p.pos].indent); err != nil {
+					return nil, err
+				}
+			}
+			sequence = append(sequence, item)
+			continue
+		}
+		// the item content is parsed as if it was on its own line, indented to its column
+		offset := indent + len(line.text) - len(rest)
+		p.lines[p.pos] = yamlLine{indent: offset, text: rest, number: line.number}
+		item, err := p.parseNode(offset)
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, item)
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return sequence, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	mapping := make(map[string]interface{})
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		key, rest, ok := splitYAMLKey(p.lines[p.pos].text)
+		if !ok {
+			return nil, p.errorf("expected a key")
+		}
+		if _, found := mapping[key]; found {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		var value interface{}
+		var err error
+		switch {
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			return nil, p.errorf("block scalars are not supported")
+		case rest != "":
+			if value, err = parseFlowValue(rest); err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			p.pos++
+		default:
+			p.pos++
+			if p.pos < len(p.lines) {
+				next := p.lines[p.pos]
+				if next.indent > indent {
+					value, err = p.parseNode(next.indent)
+				} else if next.indent == indent && isSequenceItem(next.text) {
+					value, err = p.parseSequence(indent)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		mapping[key] = value
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return mapping, nil
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits "key: value" into its key and value, the key may be quoted.
+func splitYAMLKey(text string) (string, string, bool) {
+	if text == "" || text[0] == '[' || text[0] == '{' {
+		return "", "", false
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		key, n, err := parseQuoted(text)
+		if err != nil || !strings.HasPrefix(text[n:], ":") {
+			return "", "", false
+		}
+		rest := text[n+1:]
+		if rest != "" && rest[0] != ' ' {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest), true
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a trailing "# comment" that is not inside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return line
+}
+
+// parseFlowValue parses a scalar, flow sequence or flow mapping that spans the whole text.
+func parseFlowValue(text string) (interface{}, error) {
+	f := &flowParser{text: text}
+	value, err := f.parseValue(false)
+	if err != nil {
+		return nil, err
+	}
+	f.skipSpaces()
+	if f.pos < len(f.text) {
+		return nil, fmt.Errorf("unexpected %q", f.text[f.pos:])
+	}
+	return value, nil
+}
+
+type flowParser struct {
+	text string
+	pos  int
+}
+
+func (f *flowParser) skipSpaces() {
+	for f.pos < len(f.text) && (f.text[f.pos] == ' ' || f.text[f.pos] == '\t') {
+		f.pos++
+	}
+}
+
+func (f *flowParser) parseValue(inFlow bool) (interface{}, error) {
+	f.skipSpaces()
+	if f.pos == len(f.text) {
+		return nil, nil
+	}
+	switch f.text[f.pos] {
+	case '[':
+		return f.parseFlowSequence()
+	case '{':
+		return f.parseFlowMapping()
+	case '"', '\'':
+		value, n, err := parseQuoted(f.text[f.pos:])
+		f.pos += n
+		return value, err
+	}
+	start := f.pos
+	for f.pos < len(f.text) {
+		c := f.text[f.pos]
+		if inFlow && (c == ',' || c == ']' || c == '}' || c == ':' && f.pos+1 < len(f.text) && f.text[f.pos+1] == ' ') {
+			break
+		}
+		f.pos++
+	}
+	return parseScalar(strings.TrimSpace(f.text[start:f.pos])), nil
+}
+
+func (f *flowParser) parseFlowSequence() (interface{}, error) {
+	f.pos++
+	sequence := make([]interface{}, 0)
+	for {
+		f.skipSpaces()
+		if f.pos == len(f.text) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+		if f.text[f.pos] == ']' {
+			f.pos++
+			return sequence, nil
+		}
+		item, err := f.parseValue(true)
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, item)
+		if err := f.expectSeparator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *flowParser) parseFlowMapping() (interface{}, error) {
+	f.pos++
+	mapping := make(map[string]interface{})
+	for {
+		f.skipSpaces()
+		if f.pos == len(f.text) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+		if f.text[f.pos] == '}' {
+			f.pos++
+			return mapping, nil
+		}
+		key, err := f.parseValue(true)
+		if err != nil {
+			return nil, err
+		}
+		f.skipSpaces()
+		if f.pos == len(f.text) || f.text[f.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' after key %v", key)
+		}
+		f.pos++
+		value, err := f.parseValue(true)
+		if err != nil {
+			return nil, err
+		}
+		mapping[fmt.Sprint(key)] = value
+		if err := f.expectSeparator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *flowParser) expectSeparator(end byte) error {
+	f.skipSpaces()
+	if f.pos < len(f.text) && f.text[f.pos] == ',' {
+		f.pos++
+		return nil
+	}
+	if f.pos < len(f.text) && f.text[f.pos] == end {
+		return nil
+	}
+	return fmt.Errorf("expected ',' or '%c'", end)
+}
+
+// parseQuoted parses a double quoted string with escapes, or a single quoted string where a doubled quote is a quote.
+// It returns the value and the number of bytes consumed.
+func parseQuoted(text string) (string, int, error) {
+	quote := text[0]
+	var b strings.Builder
+	for i := 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\' && quote == '"' && i+1 < len(text):
+			i++
+			switch text[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\', '/':
+				b.WriteByte(text[i])
+			case 'x', 'u', 'U':
+				r, n, err := parseEscapedRune(text[i:])
+				if err != nil {
+					return "", i, err
+				}
+				b.WriteRune(r)
+				i += n - 1
+			default:
+				// unknown escapes are kept, so regexes like "\W" can be written without doubling the backslash
+				b.WriteByte('\\')
+				b.WriteByte(text[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", len(text), fmt.Errorf("unterminated quoted string")
+}
+
+// parseEscapedRune decodes the rune of a \xXX, \uXXXX or \UXXXXXXXX escape without its backslash,
+// and returns the length of the escape. A \u escape of a high surrogate is combined with the low surrogate
+// escape after it, like in JSON.
+func parseEscapedRune(text string) (rune, int, error) {
+	digits := 8
+	switch text[0] {
+	case 'x':
+		digits = 2
+	case 'u':
+		digits = 4
+	}
+	if len(text) < 1+digits {
+		return 0, 0, fmt.Errorf("invalid escape \\%s", text)
+	}
+	code, err := strconv.ParseUint(text[1:1+digits], 16, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid escape \\%s", text[:1+digits])
+	}
+	r, n := rune(code), 1+digits
+	if utf16.IsSurrogate(r) && strings.HasPrefix(text[n:], "\\u") {
+		if low, m, err := parseEscapedRune(text[n+1:]); err == nil {
+			if combined := utf16.DecodeRune(r, low); combined != utf8.RuneError {
+				return combined, n + 1 + m, nil
+			}
+		}
+	}
+	return r, n, nil
+}
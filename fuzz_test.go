@@ -0,0 +1,134 @@
+package goclean
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The seed corpus of the fuzz targets is in testdata/fuzz, run them with e.g.
+//
+//	go test -run '^$' -fuzz FuzzRedact
+
+func FuzzList(f *testing.F) {
+	f.Fuzz(func(t *testing.T, message string) {
+		concerns := List(message)
+		checkConcerns(t, sanitizeString(message, InvalidUTF8Replace), concerns)
+		if profane := IsProfane(message); profane != (len(concerns) > 0) {
+			t.Errorf("IsProfane(%q) = %t, but List found %d concerns", message, profane, len(concerns))
+		}
+	})
+}
+
+func FuzzRedact(f *testing.F) {
+	f.Fuzz(func(t *testing.T, message string) {
+		sanitized := sanitizeString(message, InvalidUTF8Replace)
+		redacted := Redact(message)
+		replacement := gc.config.ReplacementCharacter
+		checkRedacted(t, sanitized, redacted, List(message), replacement)
+		if again := Redact(redacted); again != redacted {
+			t.Errorf("Redact is not idempotent: Redact(%q) = %q, then %q", message, redacted, again)
+		}
+	})
+}
+
+func FuzzSanitizeString(f *testing.F) {
+	f.Fuzz(func(t *testing.T, message string) {
+		for _, policy := range []InvalidUTF8Policy{InvalidUTF8Replace, InvalidUTF8Drop} {
+			sanitized := sanitizeString(message, policy)
+			if isASCII(message) && sanitized != message {
+				t.Errorf("ASCII message %q changed to %q", message, sanitized)
+			}
+			if !utf8.ValidString(sanitized) {
+				t.Fatalf("sanitizeString(%q, %s) = %q is not valid UTF-8", message, policy, sanitized)
+			}
+			if again := sanitizeString(sanitized, policy); again != sanitized {
+				t.Errorf("sanitizeString is not idempotent: %q, then %q", sanitized, again)
+			}
+			for _, r := range sanitized {
+				if unicode.Is(unicode.Mn, r) {
+					t.Errorf("sanitizeString(%q, %s) = %q keeps the mark %U", message, policy, sanitized, r)
+				}
+			}
+		}
+	})
+}
+
+func FuzzRedactHTML(f *testing.F) {
+	f.Fuzz(func(t *testing.T, document string) {
+		for _, concern := range ListHTML(document) {
+			if concern.StartIndex < 0 || int(concern.EndIndex) > len(document) || concern.StartIndex >= concern.EndIndex {
+				t.Fatalf("concern %+v is out of bounds of %q", concern, document)
+			}
+		}
+		if redacted := RedactHTML(document); utf8.ValidString(document) && !utf8.ValidString(redacted) {
+			t.Errorf("RedactHTML(%q) = %q is not valid UTF-8", document, redacted)
+		}
+	})
+}
+
+func FuzzRedactMarkdown(f *testing.F) {
+	f.Fuzz(func(t *testing.T, document string, code, urls bool) {
+		opts := MarkdownOptions{Code: code, URLs: urls}
+		for _, concern := range ListMarkdown(document, opts) {
+			if concern.StartIndex < 0 || int(concern.EndIndex) > len(document) || concern.StartIndex >= concern.EndIndex {
+				t.Fatalf("concern %+v is out of bounds of %q", concern, document)
+			}
+		}
+		if redacted := RedactMarkdown(document, opts); utf8.ValidString(document) && !utf8.ValidString(redacted) {
+			t.Errorf("RedactMarkdown(%q) = %q is not valid UTF-8", document, redacted)
+		}
+	})
+}
+
+// checkConcerns checks that the concerns are within the bounds of the sanitized message,
+// match its text and do not overlap.
+func checkConcerns(t *testing.T, sanitized string, concerns []DetectedConcern) {
+	t.Helper()
+	var matched spanSet
+	for _, concern := range concerns {
+		start, end := int(concern.StartIndex), int(concern.EndIndex)
+		if start < 0 || end > len(sanitized) || start >= end {
+			t.Fatalf("concern %+v is out of bounds of %q", concern, sanitized)
+		}
+		if concern.MatchedText != sanitized[start:end] {
+			t.Errorf("concern %+v does not match the text %q", concern, sanitized[start:end])
+		}
+		for i := start; i < end; i++ {
+			if matched.contains(i) {
+				t.Fatalf("concern %+v overlaps another concern in %q", concern, sanitized)
+			}
+		}
+		matched.add(start, end)
+	}
+}
+
+// checkRedacted checks that the concerns are replaced rune by rune in the redacted message. Other runes are kept,
+// or replaced as well where the replacement forms profanities with the text around it. The replacement is one rune.
+func checkRedacted(t *testing.T, sanitized, redacted string, concerns []DetectedConcern, replacement string) {
+	t.Helper()
+	checkConcerns(t, sanitized, concerns)
+	if !utf8.ValidString(redacted) {
+		t.Fatalf("Redact returned invalid UTF-8 %q for %q", redacted, sanitized)
+	}
+	want, got := []rune(sanitized), []rune(redacted)
+	if len(got) != len(want) {
+		t.Fatalf("Redact(%q) = %q changes the number of runes", sanitized, redacted)
+	}
+	replaced := make([]bool, len(want))
+	for _, concern := range concerns {
+		start := utf8.RuneCountInString(sanitized[:concern.StartIndex])
+		end := utf8.RuneCountInString(sanitized[:concern.EndIndex])
+		for i := start; i < end; i++ {
+			replaced[i] = true
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(replacement)
+	for i := range want {
+		if replaced[i] && got[i] != r {
+			t.Errorf("Redact(%q) = %q keeps the rune %d of a concern", sanitized, redacted, i)
+		} else if got[i] != want[i] && got[i] != r {
+			t.Errorf("Redact(%q) = %q changes the rune %d", sanitized, redacted, i)
+		}
+	}
+}
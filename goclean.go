@@ -1,14 +1,12 @@
 package goclean
 
 import (
+	"context"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
-
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 )
 
 var gc = NewProfanitySanitizer(DefaultConfig())
@@ -16,83 +14,392 @@ var gc = NewProfanitySanitizer(DefaultConfig())
 // ProfanitySanitizer contains the dictionaries as well as the configuration
 // for determining how profanity detection is handled
 type ProfanitySanitizer struct {
-	config Config
+	config         Config
+	falsePositives []*regexp.Regexp
 }
 
-// DetectedConcern contains details about detected profanity (matched text, base word, start, end index and optional level).
+// DetectedConcern contains details about detected profanity (matched text, base word, start, end index, optional level and category).
 type DetectedConcern struct {
 	Word        string
 	MatchedText string
 	StartIndex  int32
 	EndIndex    int32
 	Level       int32
+	Category    string
+	// Expansion is the expansion of the matched WordMatcher, e.g. what an acronym stands for.
+	Expansion string
+
+	// RuneStart, UTF16Start and GraphemeStart and the corresponding ends are the offsets of the concern
+	// in runes, UTF-16 code units and grapheme clusters. They are only set by ListWithOptions, see ListOptions.
+	RuneStart, RuneEnd         int32
+	UTF16Start, UTF16End       int32
+	GraphemeStart, GraphemeEnd int32
 }
 
 // List takes in a string (word or sentence) and returns list of DetectedConcern.
 func (gc *ProfanitySanitizer) List(message string) []DetectedConcern {
-	str := sanitizeString(message)
-	detected := make([]DetectedConcern, 0)
-	matched := make(map[int]bool)
-	detected = append(detected, gc.detectConcerns(str, gc.config.FalseNegatives, matched)...)
-	for _, falsePositive := range gc.config.FalsePositives {
-		if falsePositive != "" {
-			indexes := regexp.MustCompile(falsePositive).FindAllStringIndex(str, -1)
-			for _, index := range indexes {
-				putIndexesToMap(index, matched)
+	return gc.AppendList(make([]DetectedConcern, 0), message)
+}
+
+// AppendList works like List, but appends the detected concerns to dst and returns the extended slice.
+//
+// Reusing dst between calls avoids allocating a new slice for every message.
+func (gc *ProfanitySanitizer) AppendList(dst []DetectedConcern, message string) []DetectedConcern {
+	detected, _ := gc.appendList(context.Background(), dst, message)
+	return detected
+}
+
+func (gc *ProfanitySanitizer) list(ctx context.Context, message string) ([]DetectedConcern, error) {
+	return gc.appendList(ctx, make([]DetectedConcern, 0), message)
+}
+
+func (gc *ProfanitySanitizer) appendList(ctx context.Context, dst []DetectedConcern, message string) ([]DetectedConcern, error) {
+	s := getScratch()
+	defer putScratch(s)
+	return gc.detect(ctx, dst, s.sanitize(message, gc.config.InvalidUTF8), &s.matched)
+}
+
+// detect appends the concerns of an already sanitized message to dst.
+func (gc *ProfanitySanitizer) detect(ctx context.Context, dst []DetectedConcern, str string, matched *spanSet) ([]DetectedConcern, error) {
+	return gc.detectIn(ctx, dst, str, matched, detectOptions{})
+}
+
+// detectOptions changes how detectIn detects the concerns of a message.
+type detectOptions struct {
+	// contexts are the context tags the allow rules are applied for
+	contexts []string
+	// suppressed collects the concerns allowed by the allow rules if it's not nil
+	suppressed *[]SuppressedConcern
+	// inWords matches acronyms that are not whole tokens, for text without token boundaries like identifiers
+	inWords bool
+}
+
+// detectIn works like detect, with the given options.
+func (gc *ProfanitySanitizer) detectIn(ctx context.Context, dst []DetectedConcern, str string, matched *spanSet, opts detectOptions) ([]DetectedConcern, error) {
+	detected, err := gc.detectConcerns(ctx, dst, str, gc.config.FalseNegatives, matched, opts.inWords)
+	if err != nil {
+		return dst, err
+	}
+	if err := gc.markFalsePositives(ctx, str, matched); err != nil {
+		return dst, err
+	}
+	detected, err = gc.detectConcerns(ctx, detected, str, gc.config.Profanities, matched, opts.inWords)
+	if err != nil {
+		return dst, err
+	}
+	if len(gc.config.Emoji) > 0 {
+		detected, err = gc.detectEmoji(ctx, detected, str, matched)
+		if err != nil {
+			return dst, err
+		}
+	}
+	if len(gc.config.AllowRules) > 0 {
+		detected = append(detected[:len(dst)], gc.applyAllowRules(detected[len(dst):], str, opts.contexts, opts.suppressed)...)
+	}
+	return detected, nil
+}
+
+func newConcern(m WordMatcher, message string, start, end int) DetectedConcern {
+	return DetectedConcern{
+		Word:        m.Word,
+		MatchedText: message[start:end],
+		StartIndex:  int32(start),
+		EndIndex:    int32(end),
+		Level:       m.Level,
+		Category:    m.Category,
+		Expansion:   m.Expansion,
+	}
+}
+
+// detectEmoji detects the emoji matchers, matches that start or end inside a grapheme cluster
+// (e.g. a single emoji of a ZWJ sequence, or half of a flag) are ignored.
+func (gc *ProfanitySanitizer) detectEmoji(ctx context.Context, detected []DetectedConcern, message string, matched *spanSet) ([]DetectedConcern, error) {
+	var boundaries []int
+	for _, emoji := range gc.config.Emoji {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if emoji.Matcher == nil || !emoji.Matcher.MatchString(message) {
+			continue
+		}
+		if boundaries == nil {
+			boundaries = graphemeBoundaries(make([]int, 0, len(message)+1), message)
+		}
+		for _, index := range emoji.Matcher.FindAllStringIndex(message, -1) {
+			start, end := index[0], index[1]
+			if start == end || !containsInt(boundaries, start) || !containsInt(boundaries, end) || matched.isAlreadyMatched(start, end) {
+				continue
 			}
+			detected = append(detected, newConcern(emoji, message, start, end))
+			matched.add(start, end)
 		}
 	}
-	detected = append(detected, gc.detectConcerns(str, gc.config.Profanities, matched)...)
-	return detected
+	return detected, nil
+}
+
+// isWholeToken reports whether the match is not preceded or followed by a letter or a digit.
+func isWholeToken(message string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(message[:start]); start > 0 && isTokenRune(before) {
+		return false
+	}
+	after, _ := utf8.DecodeRuneInString(message[end:])
+	return end == len(message) || !isTokenRune(after)
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// containsInt reports whether the sorted values contain value.
+func containsInt(values []int, value int) bool {
+	i := sort.SearchInts(values, value)
+	return i < len(values) && values[i] == value
+}
+
+func (gc *ProfanitySanitizer) markFalsePositives(ctx context.Context, message string, matched *spanSet) error {
+	for _, falsePositive := range gc.falsePositives {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !falsePositive.MatchString(message) {
+			continue
+		}
+		for _, index := range falsePositive.FindAllStringIndex(message, -1) {
+			matched.add(index[0], index[1])
+		}
+	}
+	return nil
 }
 
-func (gc ProfanitySanitizer) detectConcerns(message string, matchers []WordMatcher, matched map[int]bool) []DetectedConcern {
-	detected := make([]DetectedConcern, 0)
+// detectConcerns appends the matches of the matchers that are not already matched. Acronyms are only matched
+// as whole tokens, unless inWords is set.
+func (gc *ProfanitySanitizer) detectConcerns(ctx context.Context, detected []DetectedConcern, message string, matchers []WordMatcher, matched *spanSet, inWords bool) ([]DetectedConcern, error) {
 	for _, profanity := range matchers {
-		if profanity.Matcher != nil {
-			indexes := profanity.Matcher.FindAllStringIndex(message, -1)
-			for _, index := range indexes {
-				start := index[0]
-				end := index[1]
-				if !isAlreadyMatched(start, end, matched) {
-					detected = append(detected, DetectedConcern{
-						Word:        profanity.Word,
-						MatchedText: message[start:end],
-						StartIndex:  int32(start),
-						EndIndex:    int32(end),
-						Level:       profanity.Level,
-					})
-					putIndexesToMap(index, matched)
-				}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if profanity.Matcher == nil {
+			continue
+		}
+		err := findAll(ctx, profanity, message, func(start, end int) bool {
+			if profanity.Kind == KindAcronym && !inWords && !isWholeToken(message, start, end) {
+				return true
+			}
+			if !matched.isAlreadyMatched(start, end) {
+				detected = append(detected, newConcern(profanity, message, start, end))
+				matched.add(start, end)
 			}
+			return true
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
-	return detected
+	return detected, nil
+}
+
+// scanChunkSize is the number of bytes in which a match may start that findAll scans before checking the context.
+const scanChunkSize = 4 << 10
+
+// findAll calls match with the matches of the matcher in the message, like FindAllStringIndex, until it returns false.
+// The message is scanned in chunks, each extended by the maximum length of a match so matches across the chunk
+// boundary are found like in the whole message, and the context is checked between the chunks. Matchers whose
+// matches are not bounded are run on the whole message.
+func findAll(ctx context.Context, m WordMatcher, message string, match func(start, end int) bool) error {
+	if m.maxLength == 0 || len(message) <= scanChunkSize+m.maxLength {
+		if !m.Matcher.MatchString(message) {
+			return nil
+		}
+		for _, index := range m.Matcher.FindAllStringIndex(message, -1) {
+			if !match(index[0], index[1]) {
+				return nil
+			}
+		}
+		return nil
+	}
+	for pos := 0; pos < len(message); {
+		chunkEnd := runeStart(message, pos+scanChunkSize)
+		end := runeStart(message, chunkEnd+m.maxLength)
+		index := m.Matcher.FindStringIndex(message[pos:end])
+		if index != nil && (pos+index[0] < chunkEnd || end == len(message)) {
+			if !match(pos+index[0], pos+index[1]) {
+				return nil
+			}
+			pos += index[1]
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pos = chunkEnd
+	}
+	return nil
+}
+
+// runeStart returns the index of the first rune that starts at or after i, or the length of the message.
+func runeStart(message string, i int) int {
+	if i >= len(message) {
+		return len(message)
+	}
+	for i < len(message) && !utf8.RuneStart(message[i]) {
+		i++
+	}
+	return i
 }
 
 // Redact takes in a string (word or sentence) and tries to censor all profanities found.
+// Redacting the result again leaves it unchanged.
 func (gc *ProfanitySanitizer) Redact(str string) string {
-	redacted := sanitizeString(str)
-	detected := gc.List(redacted)
+	redacted, _ := gc.redact(context.Background(), str)
+	return redacted
+}
+
+func (gc *ProfanitySanitizer) redact(ctx context.Context, str string) (string, error) {
+	sanitized := sanitizeString(str, gc.config.InvalidUTF8)
+	detected, err := gc.list(ctx, sanitized)
+	if err != nil {
+		return "", err
+	}
+	redacted := gc.redactConcerns(sanitized, detected)
+	// the replacement can be read as obfuscation of the text around it, e.g. "AA$Ass$" is redacted to "AA$***$"
+	// where "A$***$" matches "ass" again, so the profanities formed with it are redacted until nothing changes and
+	// redacting the result again leaves it as it is. Every pass replaces more of the message if the replacement is
+	// at most one rune, longer replacements could grow the message forever, so they are only applied once.
+	for len(detected) > 0 && utf8.RuneCountInString(gc.config.ReplacementCharacter) <= 1 {
+		if detected, err = gc.list(ctx, redacted); err != nil {
+			return "", err
+		}
+		next := gc.redactConcerns(redacted, detected)
+		if next == redacted {
+			break
+		}
+		redacted = next
+	}
+	return redacted, nil
+}
+
+// redactConcerns replaces the concerns detected in the sanitized message.
+func (gc *ProfanitySanitizer) redactConcerns(sanitized string, detected []DetectedConcern) string {
+	if len(detected) == 0 {
+		return sanitized
+	}
+	// the replacement can have a different length than the matched text, so the message is rebuilt
+	// in order of the concerns instead of replacing them in place
+	sort.Slice(detected, func(i, j int) bool { return detected[i].StartIndex < detected[j].StartIndex })
+	var redacted strings.Builder
+	redacted.Grow(len(sanitized))
+	last := 0
 	for _, concern := range detected {
-		redacted = redacted[:concern.StartIndex] + replace(concern.MatchedText, gc.config.ReplacementCharacter) + redacted[concern.EndIndex:]
+		redacted.WriteString(sanitized[last:concern.StartIndex])
+		redacted.WriteString(replace(concern.MatchedText, gc.config.ReplacementCharacter))
+		last = int(concern.EndIndex)
 	}
-	return redacted
+	redacted.WriteString(sanitized[last:])
+	return redacted.String()
 }
 
 // IsProfane checks whether there are any profanities in a given string (word or sentence).
 func (gc *ProfanitySanitizer) IsProfane(str string) bool {
-	redacted := sanitizeString(str)
-	detected := gc.List(redacted)
-	return len(detected) > 0
+	profane, _ := gc.isProfane(context.Background(), str)
+	return profane
+}
+
+// isProfane stops at the first profanity that is not suppressed by a false positive or an allow rule.
+// False positives are only evaluated once some profanity matches, as they are not
+// needed to confirm that a message is clean.
+func (gc *ProfanitySanitizer) isProfane(ctx context.Context, str string) (bool, error) {
+	s := getScratch()
+	defer putScratch(s)
+	message := s.sanitize(str, gc.config.InvalidUTF8)
+	return gc.matchesAny(ctx, message, &s.matched)
+}
+
+// matchesAny reports whether any matcher matches the sanitized message in the order detect uses them.
+func (gc *ProfanitySanitizer) matchesAny(ctx context.Context, message string, matched *spanSet) (bool, error) {
+	for _, falseNegative := range gc.config.FalseNegatives {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if found, err := gc.hasMatch(ctx, falseNegative, message, matched, nil); found || err != nil {
+			return found, err
+		}
+	}
+	falsePositivesMarked := false
+	markFalsePositives := func() error {
+		if falsePositivesMarked {
+			return nil
+		}
+		falsePositivesMarked = true
+		return gc.markFalsePositives(ctx, message, matched)
+	}
+	for _, profanity := range gc.config.Profanities {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if found, err := gc.hasMatch(ctx, profanity, message, matched, markFalsePositives); found || err != nil {
+			return found, err
+		}
+	}
+	if len(gc.config.Emoji) == 0 {
+		return false, nil
+	}
+	if err := markFalsePositives(); err != nil {
+		return false, err
+	}
+	detected, err := gc.detectEmoji(ctx, nil, message, matched)
+	if err != nil || len(gc.config.AllowRules) == 0 {
+		return len(detected) > 0, err
+	}
+	return len(gc.applyAllowRules(detected, message, nil, nil)) > 0, nil
+}
+
+// hasMatch reports whether the matcher matches the message outside of the matched spans, with a match that no
+// allow rule allows. Allowed matches are added to the matched spans, like detect does, as they still hide the
+// matches overlapping them. If mark is set, it's called before the first match is checked, to mark the spans lazily.
+func (gc *ProfanitySanitizer) hasMatch(ctx context.Context, m WordMatcher, message string, matched *spanSet, mark func() error) (bool, error) {
+	if m.Matcher == nil {
+		return false, nil
+	}
+	found := false
+	var markErr error
+	err := findAll(ctx, m, message, func(start, end int) bool {
+		if m.Kind == KindAcronym && !isWholeToken(message, start, end) {
+			return true
+		}
+		if mark != nil {
+			if markErr = mark(); markErr != nil {
+				return false
+			}
+			mark = nil
+		}
+		if matched.isAlreadyMatched(start, end) {
+			return true
+		}
+		if len(gc.config.AllowRules) > 0 && gc.allowRule(newConcern(m, message, start, end), message, nil) >= 0 {
+			matched.add(start, end)
+			return true
+		}
+		found = true
+		return false
+	})
+	if markErr != nil {
+		return false, markErr
+	}
+	return found, err
 }
 
 // NewProfanitySanitizer creates a new ProfanitySanitizer with the provided Config.
 func NewProfanitySanitizer(c *Config) ProfanitySanitizer {
 	c.Profanities = c.initializeMatchers(c.Profanities)
 	c.FalseNegatives = c.initializeMatchers(c.FalseNegatives)
+	c.Emoji = initializeEmoji(c.Emoji)
+	falsePositives, err := compileFalsePositives(c.FalsePositives)
+	if err != nil {
+		panic(err)
+	}
 	return ProfanitySanitizer{
-		config: *c,
+		config:         *c,
+		falsePositives: falsePositives,
 	}
 }
 
@@ -110,6 +417,13 @@ func List(str string) []DetectedConcern {
 	return gc.List(str)
 }
 
+// AppendList appends detected concerns to dst and returns the extended slice.
+//
+// Uses the default ProfanitySanitizer
+func AppendList(dst []DetectedConcern, str string) []DetectedConcern {
+	return gc.AppendList(dst, str)
+}
+
 // IsProfane checks whether there are any profanities in a given string (word or sentence).
 //
 // Uses the default ProfanityDetector
@@ -117,27 +431,25 @@ func IsProfane(str string) bool {
 	return gc.IsProfane(str)
 }
 
-func sanitizeString(message string) string {
-	bytes := make([]byte, len(message))
-	normalize := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-	_, _, err := normalize.Transform(bytes, []byte(message), true)
-	if err != nil {
-		return message
-	}
-	message = string(bytes)
-	return message
+func sanitizeString(message string, policy InvalidUTF8Policy) string {
+	s := getScratch()
+	defer putScratch(s)
+	return s.sanitize(message, policy)
 }
 
-func putIndexesToMap(indexes []int, mapIndexes map[int]bool) {
-	for i := indexes[0]; i < indexes[1]; i++ {
-		mapIndexes[i] = true
+func compileFalsePositives(falsePositives []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(falsePositives))
+	for _, falsePositive := range falsePositives {
+		if falsePositive == "" {
+			continue
+		}
+		re, err := regexp.Compile(falsePositive)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
 	}
-}
-
-func isAlreadyMatched(start, end int, matched map[int]bool) bool {
-	_, startFound := matched[start]
-	_, endFound := matched[end]
-	return startFound || endFound
+	return compiled, nil
 }
 
 func replace(str string, replaceChar string) string {
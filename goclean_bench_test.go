@@ -1,9 +1,16 @@
 package goclean
 
 import (
+	"strings"
 	"testing"
 )
 
+var (
+	veryLongClean          = strings.Repeat("Hello John Doe, I hope you're feeling well, as I come today bearing terrible news regarding your favorite chocolate chip cookie brand. ", 20)
+	veryLongProfaneAtStart = "Fuck " + veryLongClean
+	veryLongProfaneAtEnd   = veryLongClean + " fuck"
+)
+
 func BenchmarkIsProfaneWhenShortStringHasNoProfanity(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		IsProfane("aaaaaaaaaaaaaa")
@@ -88,6 +95,72 @@ func BenchmarkIsProfaneWhenLongStringHasProfanityAtTheEnd(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func BenchmarkIsProfaneWhenVeryLongStringHasNoProfanity(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		IsProfane(veryLongClean)
+	}
+	b.ReportAllocs()
+}
+
+func BenchmarkIsProfaneWhenVeryLongStringHasProfanityAtTheStart(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		IsProfane(veryLongProfaneAtStart)
+	}
+	b.ReportAllocs()
+}
+
+func BenchmarkIsProfaneWhenVeryLongStringHasProfanityAtTheEnd(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		IsProfane(veryLongProfaneAtEnd)
+	}
+	b.ReportAllocs()
+}
+
+// The default config has allow rules, this benchmark keeps IsProfane exiting early with a rule for the word
+// it matches first.
+func BenchmarkIsProfaneWithAllowRulesWhenVeryLongStringHasProfanityAtTheStart(b *testing.B) {
+	config := DefaultConfig()
+	config.AllowRules = append(config.AllowRules, AllowRule{Word: "fuck", Following: []string{"ing awesome"}})
+	sanitizer := NewProfanitySanitizer(config)
+	message := "Fucking awesome. " + veryLongProfaneAtStart
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sanitizer.IsProfane(message)
+	}
+	b.ReportAllocs()
+}
+
+// The List benchmarks below do the same work IsProfane did before it could exit early.
+
+func BenchmarkListWhenVeryLongStringHasNoProfanity(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = len(List(veryLongClean)) > 0
+	}
+	b.ReportAllocs()
+}
+
+func BenchmarkListWhenVeryLongStringHasProfanityAtTheStart(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = len(List(veryLongProfaneAtStart)) > 0
+	}
+	b.ReportAllocs()
+}
+
+func BenchmarkListWhenVeryLongStringHasProfanityAtTheEnd(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_ = len(List(veryLongProfaneAtEnd)) > 0
+	}
+	b.ReportAllocs()
+}
+
+func BenchmarkAppendList(b *testing.B) {
+	dst := make([]DetectedConcern, 0, 8)
+	for n := 0; n < b.N; n++ {
+		dst = AppendList(dst[:0], "Hello John Doe, I hope you're feeling well, as I come today bearing shitty news regarding your favorite chocolate chip cookie brand")
+	}
+	b.ReportAllocs()
+}
+
 func BenchmarkCensor(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		Redact("Thundercunt c()ck")
@@ -51,6 +51,10 @@ func TestGoClean_Redact(t *testing.T) {
 		{"should match false negatives", "dumbass", "*******"},
 		{"should match false positive", "bass", "bass"},
 		{"should handle multi-byte characters case insensitive", "世界 世界 ASS 世界", "世界 世界 *** 世界"},
+		{"should sanitize special characters", "fûçk", "****"},
+		{"should redact adjacent profanities", "assfuck", "*******"},
+		{"should redact profanities formed with the replacement", "AA$Ass$00000000", "A******00000000"},
+		{"should redact after multi-byte obfuscation", "a…s…s ass", "***** ***"},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -83,6 +87,11 @@ func TestGoClean_List(t *testing.T) {
 		{"should match case insensitive", "ASS", []DetectedConcern{{Word: "ass", MatchedText: "ASS", StartIndex: 0, EndIndex: 3, Level: 2}}},
 		{"should handle multi-byte characters case insensitive", "世界 世界 ASS 世界", []DetectedConcern{{Word: "ass", MatchedText: "ASS", StartIndex: 14, EndIndex: 17, Level: 2}}},
 		{"should sanitize special characters", "fûçk", []DetectedConcern{{MatchedText: "fuck", StartIndex: 0, EndIndex: 4}}},
+		{"should match profanities ending where another starts", "assfuck", []DetectedConcern{
+			{MatchedText: "fuck", StartIndex: 3, EndIndex: 7},
+			{Word: "ass", MatchedText: "ass", StartIndex: 0, EndIndex: 3, Level: 2},
+		}},
+		{"should match profanities next to a false positive", "a$$assassin", []DetectedConcern{{Word: "ass", MatchedText: "a$$", StartIndex: 0, EndIndex: 3, Level: 2}}},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -93,3 +102,80 @@ func TestGoClean_List(t *testing.T) {
 		})
 	}
 }
+
+func TestGoClean_ListAcronyms(t *testing.T) {
+	wtf := func(matched string, start, end int32) DetectedConcern {
+		return DetectedConcern{Word: "wtf", MatchedText: matched, StartIndex: start, EndIndex: end, Expansion: "what the fuck"}
+	}
+	tests := []struct {
+		name string
+		text string
+		want []DetectedConcern
+	}{
+		{"acronym", "wtf", []DetectedConcern{wtf("wtf", 0, 3)}},
+		{"case insensitive", "so WTF?", []DetectedConcern{wtf("WTF", 3, 6)}},
+		{"dots", "w.t.f.", []DetectedConcern{wtf("w.t.f", 0, 5)}},
+		{"inside a word", "awtfb", []DetectedConcern{}},
+		{"followed by a digit", "wtf2", []DetectedConcern{}},
+		{"not obfuscated", "w_t_f", []DetectedConcern{}},
+		{"level and category", "kys", []DetectedConcern{
+			{Word: "kys", MatchedText: "kys", StartIndex: 0, EndIndex: 3, Level: 3, Category: "self-harm", Expansion: "kill yourself"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := List(test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	c := DefaultConfig()
+	c.Profanities = []WordMatcher{{Word: "f.y.", Kind: KindAcronym, Expansion: "f you"}}
+	sanitizer := NewProfanitySanitizer(c)
+	if got, want := sanitizer.Redact("FY and F.Y and fyi"), "** and *** and fyi"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestGoClean_AppendList(t *testing.T) {
+	dst := make([]DetectedConcern, 0, 4)
+	dst = AppendList(dst, "hello world fuck")
+	dst = AppendList(dst, "ass")
+	want := []DetectedConcern{
+		{MatchedText: "fuck", StartIndex: 12, EndIndex: 16},
+		{Word: "ass", MatchedText: "ass", StartIndex: 0, EndIndex: 3, Level: 2},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("got %v, want %v", dst, want)
+	}
+}
+
+func TestGoClean_IsProfaneMatchesList(t *testing.T) {
+	tests := []string{
+		"hello world",
+		"hello world fuck",
+		"bass",
+		"dumbass",
+		"bass ass",
+		"bitchass",
+		"assassin",
+		"sussex shitake",
+		"a....s....s",
+		"世界 世界 ASS 世界",
+		"wtf",
+		"awtfb",
+		"🖕",
+		"🖕‍🔥",
+		"cockpit",
+		"cockpit cock",
+	}
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			want := len(List(text)) > 0
+			if got := IsProfane(text); got != want {
+				t.Errorf("got %t, want %t", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package goclean
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zeroWidthJoiner = '‍'
+	regionalStart   = '\U0001F1E6'
+	regionalEnd     = '\U0001F1FF'
+)
+
+// nextGrapheme returns the length of the first grapheme cluster of s. It implements the rules of extended
+// grapheme clusters (UAX #29) that matter for messages: CR LF, combining marks and other extenders, emoji
+// modifiers, ZWJ sequences, flags (regional indicator pairs) and Hangul syllables.
+func nextGrapheme(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return 0
+	}
+	previous := r
+	pictographic := isPictographic(r)
+	regional := 0
+	if isRegionalIndicator(r) {
+		regional = 1
+	}
+	i := size
+	for i < len(s) {
+		next, nextSize := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case previous == '\r' && next == '\n':
+		case previous == '\r' || previous == '\n' || next == '\r' || next == '\n':
+			return i
+		case isGraphemeExtend(next):
+		case previous == zeroWidthJoiner && pictographic && isPictographic(next):
+		case regional == 1 && isRegionalIndicator(next):
+			regional++
+		case joinsHangul(previous, next):
+		default:
+			return i
+		}
+		previous = next
+		i += nextSize
+	}
+	return i
+}
+
+// graphemeBoundaries appends the byte offsets of the grapheme cluster boundaries of s, including 0 and len(s).
+func graphemeBoundaries(dst []int, s string) []int {
+	dst = append(dst, 0)
+	for i := 0; i < len(s); {
+		i += nextGrapheme(s[i:])
+		dst = append(dst, i)
+	}
+	return dst
+}
+
+// isGraphemeExtend reports whether r never starts a grapheme cluster.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case r < 0x300:
+		return false
+	case r == zeroWidthJoiner, isEmojiModifier(r), r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0020 && r <= 0xE007F:
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalStart && r <= regionalEnd
+}
+
+// isPictographic approximates the Extended_Pictographic property with the blocks that contain emoji.
+func isPictographic(r rune) bool {
+	switch {
+	case r < 0xA9:
+		return false
+	case r == 0xA9, r == 0xAE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139, r == 0x3030, r == 0x303D,
+		r == 0x3297, r == 0x3299:
+		return true
+	case r >= 0x2194 && r <= 0x21AA, r >= 0x231A && r <= 0x23FF, r >= 0x25AA && r <= 0x27BF, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	}
+	return r >= 0x1F000 && r <= 0x1FAFF && !isRegionalIndicator(r) && !isEmojiModifier(r) || r >= 0x1FC00 && r <= 0x1FFFD
+}
+
+// joinsHangul reports whether the Hangul jamo or syllables previous and next are part of the same syllable.
+func joinsHangul(previous, next rune) bool {
+	p, n := hangulType(previous), hangulType(next)
+	switch p {
+	case hangulL:
+		return n == hangulL || n == hangulV || n == hangulLV || n == hangulLVT
+	case hangulLV, hangulV:
+		return n == hangulV || n == hangulT
+	case hangulLVT, hangulT:
+		return n == hangulT
+	}
+	return false
+}
+
+const (
+	hangulNone = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulType(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
@@ -0,0 +1,80 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemeBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{"empty", "", []int{0}},
+		{"ascii", "ab", []int{0, 1, 2}},
+		{"CR LF", "a\r\nb", []int{0, 1, 3, 4}},
+		{"combining marks", "éa", []int{0, 3, 4}},
+		{"skin tone modifier", "🖕🏿a", []int{0, 8, 9}},
+		{"variation selector", "❤️a", []int{0, 6, 7}},
+		{"ZWJ sequence", "👨‍👩‍👦x", []int{0, 18, 19}},
+		{"flags", "🇺🇸🇬🇧", []int{0, 8, 16}},
+		{"odd regional indicator", "🇺🇸🇬", []int{0, 8, 12}},
+		{"keycap", "1️⃣", []int{0, 7}},
+		{"hangul syllables", "한국", []int{0, 3, 6}},
+		{"hangul jamo", "각가", []int{0, 9, 12}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := graphemeBoundaries(nil, test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGoClean_ListEmoji(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []DetectedConcern
+	}{
+		{"emoji", "you 🖕", []DetectedConcern{{Word: "🖕", MatchedText: "🖕", StartIndex: 4, EndIndex: 8, Level: 2, Category: "gesture"}}},
+		{"skin tones", "🖕🏿", []DetectedConcern{{Word: "🖕", MatchedText: "🖕🏿", StartIndex: 0, EndIndex: 8, Level: 2, Category: "gesture"}}},
+		{"sequence", "🍆💦", []DetectedConcern{{Word: "🍆💦", MatchedText: "🍆💦", StartIndex: 0, EndIndex: 8, Level: 2, Category: "sexual"}}},
+		{"separated sequence", "🍆 💦", []DetectedConcern{}},
+		{"emoticon", "8====D", []DetectedConcern{{Word: "8==D", MatchedText: "8====D", StartIndex: 0, EndIndex: 6, Level: 2, Category: "sexual"}}},
+		{"part of a ZWJ sequence", "🖕‍🔥", []DetectedConcern{}},
+		{"with words", "fuck 🖕", []DetectedConcern{
+			{MatchedText: "fuck", StartIndex: 0, EndIndex: 4},
+			{Word: "🖕", MatchedText: "🖕", StartIndex: 5, EndIndex: 9, Level: 2, Category: "gesture"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := List(test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	c := DefaultConfig()
+	c.Emoji = []WordMatcher{{Word: "🖕🏿", Level: 3}, {Word: "🇽🇽"}}
+	sanitizer := NewProfanitySanitizer(c)
+	if got := sanitizer.List("🖕🏻"); len(got) != 0 {
+		t.Errorf("got %v, want no concerns for another skin tone", got)
+	}
+	if got, want := sanitizer.Redact("🖕️🏿"), "**"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got := sanitizer.List("🇺🇽🇽🇺"); len(got) != 0 {
+		t.Errorf("got %v, want no concerns across flags", got)
+	}
+	derived, err := sanitizer.Derive(Layer{Emoji: []WordMatcher{{Word: "💩"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := derived.List("💩🏽"); len(got) != 1 {
+		t.Errorf("got %v, want the emoji of the layer", got)
+	}
+}
@@ -0,0 +1,167 @@
+package goclean
+
+import (
+	"html"
+	"strings"
+)
+
+// inlineElements do not separate words, so "a<b>ss</b>" is matched like "ass".
+// All other elements, like paragraphs and line breaks, separate the text before and after them.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "bdo": true, "cite": true, "code": true, "data": true,
+	"del": true, "dfn": true, "em": true, "font": true, "i": true, "ins": true, "kbd": true, "mark": true,
+	"q": true, "s": true, "samp": true, "small": true, "span": true, "strike": true, "strong": true,
+	"sub": true, "sup": true, "time": true, "tt": true, "u": true, "var": true, "wbr": true,
+}
+
+// ListHTML detects profanities in the text of an HTML document. Tags, attributes, comments, scripts and styles
+// are ignored, entities are decoded and the text of inline elements is matched across tags.
+//
+// StartIndex and EndIndex of the concerns are byte offsets in the document, MatchedText is the decoded text without markup.
+func (gc *ProfanitySanitizer) ListHTML(document string) []DetectedConcern {
+	m := gc.mapHTML(document)
+	defer m.release()
+	return m.list(gc)
+}
+
+// RedactHTML redacts profanities in the text of an HTML document, like ListHTML detects them.
+// The markup is written back as it is, only the characters of the text are replaced.
+func (gc *ProfanitySanitizer) RedactHTML(document string) string {
+	m := gc.mapHTML(document)
+	defer m.release()
+	return m.redact(gc, document, html.EscapeString)
+}
+
+func (gc *ProfanitySanitizer) mapHTML(document string) *mappedText {
+	m := newMappedText(&gc.config)
+	for i := 0; i < len(document); {
+		switch document[i] {
+		case '<':
+			end, name, closing, ok := scanHTMLTag(document, i)
+			if !ok {
+				m.appendSource("<", i)
+				i++
+				continue
+			}
+			if name != "" && !inlineElements[name] {
+				m.appendSeparator()
+			}
+			i = end
+			if !closing && (name == "script" || name == "style") {
+				i = skipRawText(document, i, name)
+			}
+		case '&':
+			end := scanHTMLEntity(document, i)
+			if decoded := html.UnescapeString(document[i:end]); end > i+1 && decoded != document[i:end] {
+				m.appendDecoded(decoded, i, end)
+			} else {
+				m.appendSource("&", i)
+				end = i + 1
+			}
+			i = end
+		default:
+			end := strings.IndexAny(document[i:], "<&")
+			if end < 0 {
+				end = len(document) - i
+			}
+			m.appendSource(document[i:i+end], i)
+			i += end
+		}
+	}
+	return m
+}
+
+// scanHTMLTag scans the tag starting at i and returns the index after it with its lowercase name.
+// Comments, doctypes and processing instructions have an empty name. ok is false if the "<" does not start a tag.
+func scanHTMLTag(document string, i int) (end int, name string, closing bool, ok bool) {
+	rest := document[i:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		if j := strings.Index(rest[4:], "-->"); j >= 0 {
+			return i + 4 + j + 3, "", false, true
+		}
+		return len(document), "", false, true
+	case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+		if j := strings.IndexByte(rest, '>'); j >= 0 {
+			return i + j + 1, "", false, true
+		}
+		return len(document), "", false, true
+	}
+	j := 1
+	if strings.HasPrefix(rest, "</") {
+		closing = true
+		j = 2
+	}
+	if j >= len(rest) || !isASCIILetter(rest[j]) {
+		return 0, "", false, false
+	}
+	start := j
+	for j < len(rest) && (isASCIILetter(rest[j]) || rest[j] >= '0' && rest[j] <= '9' || rest[j] == '-') {
+		j++
+	}
+	name = strings.ToLower(rest[start:j])
+	// attribute values are quoted after "=", a quoted ">" does not end the tag
+	afterEquals := false
+	for ; j < len(rest); j++ {
+		switch c := rest[j]; {
+		case c == '>':
+			return i + j + 1, name, closing, true
+		case (c == '"' || c == '\'') && afterEquals:
+			if k := strings.IndexByte(rest[j+1:], c); k >= 0 {
+				j += k + 1
+			} else {
+				j = len(rest)
+			}
+			afterEquals = false
+		case c == '=':
+			afterEquals = true
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			afterEquals = false
+		}
+	}
+	return len(document), name, closing, true
+}
+
+// scanHTMLEntity returns the end of the character reference candidate starting at i.
+func scanHTMLEntity(document string, i int) int {
+	j := i + 1
+	for j < len(document) && j-i < 32 && (isASCIILetter(document[j]) || document[j] >= '0' && document[j] <= '9' || document[j] == '#') {
+		j++
+	}
+	if j < len(document) && document[j] == ';' {
+		j++
+	}
+	return j
+}
+
+// skipRawText returns the index of the end tag of a script or style element.
+func skipRawText(document string, i int, name string) int {
+	for j := i; ; j += 2 {
+		k := strings.Index(document[j:], "</")
+		if k < 0 {
+			return len(document)
+		}
+		j += k
+		if end := j + 2 + len(name); end <= len(document) && strings.EqualFold(document[j+2:end], name) {
+			return j
+		}
+	}
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ListHTML detects profanities in the text of an HTML document.
+//
+// Uses the default ProfanitySanitizer
+func ListHTML(document string) []DetectedConcern {
+	return gc.ListHTML(document)
+}
+
+// RedactHTML redacts profanities in the text of an HTML document.
+//
+// Uses the default ProfanitySanitizer
+func RedactHTML(document string) string {
+	return gc.RedactHTML(document)
+}
@@ -0,0 +1,51 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_RedactHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{"text", "<p>what the fuck</p>", "<p>what the ****</p>"},
+		{"across inline tags", "<p>a<b>ss</b> and f<i>u</i>ck</p>", "<p>*<b>**</b> and *<i>*</i>**</p>"},
+		{"block tags separate words", "<p>a</p><p>ss</p><li>sh</li><br>it", "<p>a</p><p>ss</p><li>sh</li><br>it"},
+		{"attributes are kept", `<a href="/shit?ass=1" title='fuck > shit'>link</a>`, `<a href="/shit?ass=1" title='fuck > shit'>link</a>`},
+		{"entities are decoded", "<p>sh&#105;t &amp; f&uacute;ck</p>", "<p>**** &amp; ****</p>"},
+		{"replacement is escaped", "<p>ass</p>", "<p>***</p>"},
+		{"comments, scripts and styles are ignored", "<!-- shit --><script>var ass = 1;</script><style>.fuck{}</STYLE>hello", "<!-- shit --><script>var ass = 1;</script><style>.fuck{}</STYLE>hello"},
+		{"text after a script", "<script>a < b</script>shit", "<script>a < b</script>****"},
+		{"less than is text", "1 < 2 shit", "1 < 2 ****"},
+		{"diacritics are kept outside matches", "<p>Café fûçk</p>", "<p>Café ****</p>"},
+		{"false positives", "<p>bass</p>", "<p>bass</p>"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RedactHTML(test.document); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	c := DefaultConfig()
+	c.ReplacementCharacter = "<"
+	sanitizer := NewProfanitySanitizer(c)
+	if got, want := sanitizer.RedactHTML("<b>ass</b>"), "<b>&lt;&lt;&lt;</b>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestGoClean_ListHTML(t *testing.T) {
+	got := ListHTML(`<p title="shit">a<b>ss</b> &amp; sh&#105;t</p>`)
+	want := []DetectedConcern{
+		{Word: "ass", MatchedText: "ass", StartIndex: 16, EndIndex: 22, Level: 2},
+		{Word: "shit", MatchedText: "shit", StartIndex: 33, EndIndex: 42},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,283 @@
+package goclean
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// identifierLeetSpeak maps the characters commonly used instead of letters in usernames.
+// Digits are mapped in one of the two checks of an identifier and dropped in the other one.
+var identifierLeetSpeak = map[rune]string{
+	'0': "o", '1': "i", '2': "z", '3': "e", '4': "a", '5': "s", '6': "g", '7': "t", '8': "b", '9': "g",
+	'@': "a", '$': "s", '!': "i", '|': "l", '+': "t", '€': "e", '£': "l",
+}
+
+// IdentifierResult is the verdict of CheckIdentifier.
+type IdentifierResult struct {
+	Identifier string
+	Profane    bool
+	// Concerns are the profanities found in the identifier, StartIndex, EndIndex and MatchedText refer to the identifier.
+	Concerns []DetectedConcern
+	// Segments are the parts of the identifier split on case changes, digits, underscores, dots, dashes and spaces.
+	Segments []string
+	// Suggestions are clean variants of a profane identifier.
+	Suggestions []string
+}
+
+// CheckIdentifier checks a username, handle or other identifier. As identifiers have no spaces,
+// the segments of the identifier ("xXassXx", "shit_lord99", "BigDickEnergy") are joined and matched
+// as a single word, separators are ignored and leet speak is always replaced, more aggressively than in text.
+//
+// The identifier is checked twice, with digits replaced by letters and with digits removed,
+// so both "sh1t" and "a55" as well as "ass99" are detected.
+func (gc *ProfanitySanitizer) CheckIdentifier(identifier string) IdentifierResult {
+	result := IdentifierResult{Identifier: identifier, Concerns: gc.identifierConcerns(identifier)}
+	for _, segment := range splitIdentifier(identifier) {
+		result.Segments = append(result.Segments, identifier[segment.start:segment.end])
+	}
+	result.Profane = len(result.Concerns) > 0
+	if result.Profane {
+		result.Suggestions = gc.identifierSuggestions(identifier, result.Concerns)
+	}
+	return result
+}
+
+func (gc *ProfanitySanitizer) identifierConcerns(identifier string) []DetectedConcern {
+	var concerns []DetectedConcern
+	seen := make(map[[2]int32]bool)
+	for _, digits := range []bool{true, false} {
+		m := newMappedText(&gc.config)
+		// the words of an identifier are joined without separators, like "stfu" in "stfu_now"
+		m.opts.inWords = true
+		for i, r := range identifier {
+			size := utf8.RuneLen(r)
+			if size < 0 {
+				size = 1
+			}
+			if leet, ok := identifierLeetSpeak[r]; ok && (digits || !unicode.IsDigit(r)) {
+				m.appendDecoded(leet, i, i+size)
+			} else if unicode.IsLetter(r) {
+				m.appendDecoded(identifier[i:i+size], i, i+size)
+			}
+		}
+		for _, concern := range m.list(gc) {
+			key := [2]int32{concern.StartIndex, concern.EndIndex}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			concern.MatchedText = identifier[concern.StartIndex:concern.EndIndex]
+			concerns = append(concerns, concern)
+		}
+		m.release()
+	}
+	sort.Slice(concerns, func(i, j int) bool { return concerns[i].StartIndex < concerns[j].StartIndex })
+	return concerns
+}
+
+// identifierSuggestions removes the segments with profanities, or only the profanities, from the identifier.
+func (gc *ProfanitySanitizer) identifierSuggestions(identifier string, concerns []DetectedConcern) []string {
+	segments := splitIdentifier(identifier)
+	var withoutSegments, withoutConcerns strings.Builder
+	last := 0
+	for _, segment := range segments {
+		if !overlapsAny(segment.start, segment.end, concerns) {
+			withoutSegments.WriteString(identifier[last:segment.end])
+		}
+		last = segment.end
+	}
+	last = 0
+	for _, concern := range concerns {
+		if int(concern.StartIndex) < last {
+			continue
+		}
+		withoutConcerns.WriteString(identifier[last:concern.StartIndex])
+		last = int(concern.EndIndex)
+	}
+	withoutConcerns.WriteString(identifier[last:])
+
+	var suggestions []string
+	for _, candidate := range []string{withoutSegments.String(), withoutConcerns.String()} {
+		candidate = strings.Trim(candidate, "_.- ")
+		if candidate == "" || candidate == identifier || containsString(suggestions, candidate) {
+			continue
+		}
+		if len(gc.identifierConcerns(candidate)) == 0 {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
+
+// SuggestAlternatives returns up to n clean variants of a name rejected by CheckIdentifier or IsProfane, like a
+// username or display name. The offending spans are replaced with their synonyms from Config.Synonyms, the offending
+// segments or spans are removed, and numbers are appended to the clean variants. Every variant is checked with
+// CheckIdentifier before it's returned, so no more than n variants, or none, may be returned.
+//
+// A name that is not profane has no alternatives.
+func (gc *ProfanitySanitizer) SuggestAlternatives(name string, n int) []string {
+	concerns := gc.identifierConcerns(name)
+	if n <= 0 || len(concerns) == 0 {
+		return nil
+	}
+	var suggestions []string
+	add := func(candidate string) bool {
+		if len(suggestions) < n && candidate != "" && candidate != name && !containsString(suggestions, candidate) &&
+			len(gc.identifierConcerns(candidate)) == 0 {
+			suggestions = append(suggestions, candidate)
+		}
+		return len(suggestions) < n
+	}
+	for _, candidate := range gc.synonymVariants(name, concerns) {
+		if !add(candidate) {
+			return suggestions
+		}
+	}
+	for _, candidate := range gc.identifierSuggestions(name, concerns) {
+		if !add(candidate) {
+			return suggestions
+		}
+	}
+	bases := append([]string(nil), suggestions...)
+	if len(bases) == 0 {
+		return suggestions
+	}
+	for number := 1; number < 1000; number++ {
+		base := bases[(number-1)%len(bases)]
+		separator := ""
+		if last, _ := utf8.DecodeLastRuneInString(base); unicode.IsDigit(last) {
+			separator = "_"
+		}
+		if !add(base + separator + strconv.Itoa(number)) {
+			break
+		}
+	}
+	return suggestions
+}
+
+// synonymVariants replaces every concern with one of its synonyms, the i-th variant uses the i-th synonym of every concern.
+// Concerns without synonyms are kept, so the variant is rejected if it's the only one.
+func (gc *ProfanitySanitizer) synonymVariants(name string, concerns []DetectedConcern) []string {
+	var variants []string
+	for i := 0; ; i++ {
+		var b strings.Builder
+		replaced := false
+		last := 0
+		for _, concern := range concerns {
+			if int(concern.StartIndex) < last {
+				continue
+			}
+			b.WriteString(name[last:concern.StartIndex])
+			last = int(concern.EndIndex)
+			synonyms := gc.config.Synonyms[strings.ToLower(concern.Word)]
+			if concern.Word == "" {
+				synonyms = gc.config.Synonyms[strings.ToLower(concern.MatchedText)]
+			}
+			if i < len(synonyms) {
+				b.WriteString(matchCase(synonyms[i], concern.MatchedText))
+				replaced = true
+			} else {
+				b.WriteString(concern.MatchedText)
+			}
+		}
+		if !replaced {
+			return variants
+		}
+		b.WriteString(name[last:])
+		variants = append(variants, b.String())
+	}
+}
+
+// matchCase converts the word to upper case, or capitalizes it, like the original.
+func matchCase(word, original string) string {
+	first, _ := utf8.DecodeRuneInString(original)
+	switch {
+	case !unicode.IsUpper(first):
+		return word
+	case original == strings.ToUpper(original) && utf8.RuneCountInString(original) > 1:
+		return strings.ToUpper(word)
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+type identifierSegment struct {
+	start, end int
+}
+
+// splitIdentifier splits the identifier on separators, digits and case changes ("HTTPServer" is split into "HTTP" and "Server").
+func splitIdentifier(identifier string) []identifierSegment {
+	var segments []identifierSegment
+	start := -1
+	var previous rune
+	for i, r := range identifier {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				segments = append(segments, identifierSegment{start, i})
+			}
+			start = -1
+			continue
+		}
+		if start >= 0 && isIdentifierBoundary(previous, r, identifier[i+utf8.RuneLen(r):]) {
+			segments = append(segments, identifierSegment{start, i})
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+		previous = r
+	}
+	if start >= 0 {
+		segments = append(segments, identifierSegment{start, len(identifier)})
+	}
+	return segments
+}
+
+// isIdentifierBoundary reports whether a new segment starts with r, which follows previous.
+func isIdentifierBoundary(previous, r rune, rest string) bool {
+	switch {
+	case unicode.IsDigit(previous) != unicode.IsDigit(r):
+		return true
+	case unicode.IsLower(previous) && unicode.IsUpper(r):
+		return true
+	case unicode.IsUpper(previous) && unicode.IsUpper(r):
+		next, _ := utf8.DecodeRuneInString(rest)
+		return unicode.IsLower(next)
+	}
+	return false
+}
+
+func overlapsAny(start, end int, concerns []DetectedConcern) bool {
+	for _, concern := range concerns {
+		if int(concern.StartIndex) < end && start < int(concern.EndIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIdentifier checks a username, handle or other identifier.
+//
+// Uses the default ProfanitySanitizer
+func CheckIdentifier(identifier string) IdentifierResult {
+	return gc.CheckIdentifier(identifier)
+}
+
+// SuggestAlternatives returns up to n clean variants of a profane name.
+//
+// Uses the default ProfanitySanitizer
+func SuggestAlternatives(name string, n int) []string {
+	return gc.SuggestAlternatives(name, n)
+}
@@ -0,0 +1,92 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_CheckIdentifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		identifier  string
+		profane     bool
+		segments    []string
+		suggestions []string
+	}{
+		{"clean", "john.smith", false, []string{"john", "smith"}, nil},
+		{"clean with digits", "user1234", false, []string{"user", "1234"}, nil},
+		{"inside a segment", "xXassXx", true, []string{"x", "Xass", "Xx"}, []string{"xXx", "xXXx"}},
+		{"underscores and digits", "shit_lord99", true, []string{"shit", "lord", "99"}, []string{"lord99"}},
+		{"case changes", "BigDickEnergy", true, []string{"Big", "Dick", "Energy"}, []string{"BigEnergy"}},
+		{"leet speak", "a55hole", true, []string{"a", "55", "hole"}, nil},
+		{"digits are removed", "ass99", true, []string{"ass", "99"}, []string{"99"}},
+		{"across separators", "s.h.i.t", true, []string{"s", "h", "i", "t"}, nil},
+		{"acronyms", "HTTPServer", false, []string{"HTTP", "Server"}, nil},
+		{"profane acronym with underscores", "stfu_now", true, []string{"stfu", "now"}, []string{"now"}},
+		{"profane acronym with case changes", "gtfoNoob", true, []string{"gtfo", "Noob"}, []string{"Noob"}},
+		{"false positives", "Glasses", false, []string{"Glasses"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := CheckIdentifier(test.identifier)
+			if got.Profane != test.profane {
+				t.Errorf("got profane %v, want %v: %v", got.Profane, test.profane, got.Concerns)
+			}
+			if !reflect.DeepEqual(got.Segments, test.segments) {
+				t.Errorf("got segments %q, want %q", got.Segments, test.segments)
+			}
+			if !reflect.DeepEqual(got.Suggestions, test.suggestions) {
+				t.Errorf("got suggestions %q, want %q", got.Suggestions, test.suggestions)
+			}
+		})
+	}
+
+	got := CheckIdentifier("sh1t_lord")
+	want := []DetectedConcern{{Word: "shit", MatchedText: "sh1t", StartIndex: 0, EndIndex: 4}}
+	if !reflect.DeepEqual(got.Concerns, want) {
+		t.Errorf("got %v, want %v", got.Concerns, want)
+	}
+}
+
+func TestGoClean_SuggestAlternatives(t *testing.T) {
+	c := DefaultConfig()
+	c.Synonyms = map[string][]string{"ass": {"donkey", "mule"}, "dick": {"duck"}}
+	sanitizer := NewProfanitySanitizer(c)
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{"john", 3, nil},
+		{"xXassXx", 3, []string{"xXdonkeyXx", "xXmuleXx", "xXx"}},
+		{"ASS", 3, []string{"DONKEY", "MULE", "DONKEY1"}},
+		{"BigDickEnergy", 4, []string{"BigDuckEnergy", "BigEnergy", "BigDuckEnergy1", "BigEnergy2"}},
+		{"shit_lord99", 3, []string{"lord99", "lord99_1", "lord99_2"}},
+		{"a55hole", 3, nil},
+		{"xXassXx", 0, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := sanitizer.SuggestAlternatives(test.name, test.n)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			for _, suggestion := range got {
+				if sanitizer.CheckIdentifier(suggestion).Profane {
+					t.Errorf("suggestion %q is profane", suggestion)
+				}
+			}
+		})
+	}
+
+	derived, err := sanitizer.Derive(Layer{Synonyms: map[string][]string{"ass": {"burro"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := derived.SuggestAlternatives("ass", 3), []string{"donkey", "mule", "burro"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := sanitizer.SuggestAlternatives("ass", 3), []string{"donkey", "mule", "donkey1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("base changed: got %q, want %q", got, want)
+	}
+}
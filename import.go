@@ -0,0 +1,366 @@
+package goclean
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ImportFormat is the format of a word list that can be converted with Import.
+type ImportFormat int
+
+const (
+	// ImportText is a list with one word or phrase per line, like the LDNOOBW lists.
+	// Empty lines and lines starting with "#" are ignored.
+	ImportText ImportFormat = iota
+	// ImportCSV is a CSV file with a word column and optional severity and category columns.
+	// Columns are identified by the header, a file without header has the word in the first column
+	// and the severity in the second one.
+	ImportCSV
+	// ImportJSON is a JSON array of words, an object with a "words" array, or an array of objects
+	// with a word ("word", "term" or "match" with "|" separated alternatives), severity ("severity" or "level"),
+	// category ("category" or the first of "tags") and false positives ("exceptions").
+	ImportJSON
+)
+
+// ParseImportFormat returns the ImportFormat with the given name (text, ldnoobw, csv or json).
+func ParseImportFormat(name string) (ImportFormat, error) {
+	switch strings.ToLower(name) {
+	case "text", "txt", "ldnoobw":
+		return ImportText, nil
+	case "csv":
+		return ImportCSV, nil
+	case "json":
+		return ImportJSON, nil
+	}
+	return 0, fmt.Errorf("goclean: unknown import format %q", name)
+}
+
+// DetectImportFormat detects the ImportFormat from the extension of the file name, defaulting to ImportText.
+func DetectImportFormat(name string) ImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ImportCSV
+	case ".json":
+		return ImportJSON
+	}
+	return ImportText
+}
+
+// ImportIssue is an entry of an imported word list that could not be converted.
+type ImportIssue struct {
+	// Line is the line of the entry for text and CSV lists, or its index for JSON lists (starting at 1).
+	Line   int
+	Entry  string
+	Reason string
+}
+
+// ImportReport contains the results of Import.
+type ImportReport struct {
+	// Added are the profanities added to the Config.
+	Added []WordMatcher
+	// AddedFalsePositives are the false positives added to the Config.
+	AddedFalsePositives []string
+	// Duplicates are entries that were skipped, because they are already in the Config.
+	Duplicates []string
+	// Skipped are entries that could not be converted.
+	Skipped []ImportIssue
+}
+
+// Import converts the word list read from r and adds its entries to the profanities of c.
+// Entries that already exist in c are not added again.
+func Import(c *Config, r io.Reader, format ImportFormat) (*ImportReport, error) {
+	var entries []importEntry
+	var err error
+	switch format {
+	case ImportText:
+		entries, err = readTextList(r)
+	case ImportCSV:
+		entries, err = readCSVList(r)
+	case ImportJSON:
+		entries, err = readJSONList(r)
+	default:
+		return nil, fmt.Errorf("goclean: unknown import format %d", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+	known := make(map[string]bool)
+	for _, m := range append(copyMatchers(c.Profanities), c.FalseNegatives...) {
+		known[matcherKey(m)] = true
+	}
+	for _, fp := range c.FalsePositives {
+		known["fp:"+fp] = true
+	}
+	for _, entry := range entries {
+		if entry.reason != "" {
+			report.Skipped = append(report.Skipped, ImportIssue{Line: entry.line, Entry: entry.text, Reason: entry.reason})
+			continue
+		}
+		for _, fp := range entry.falsePositives {
+			if !known["fp:"+fp] {
+				known["fp:"+fp] = true
+				c.FalsePositives = append(c.FalsePositives, fp)
+				report.AddedFalsePositives = append(report.AddedFalsePositives, fp)
+			}
+		}
+		for _, m := range entry.matchers {
+			if _, err := c.compileMatcher(m); err != nil {
+				report.Skipped = append(report.Skipped, ImportIssue{Line: entry.line, Entry: entry.text, Reason: "invalid pattern: " + err.Error()})
+				continue
+			}
+			key := matcherKey(m)
+			if known[key] {
+				report.Duplicates = append(report.Duplicates, m.Word+m.Regex)
+				continue
+			}
+			known[key] = true
+			c.Profanities = append(c.Profanities, m)
+			report.Added = append(report.Added, m)
+		}
+	}
+	return report, nil
+}
+
+// matcherKey identifies matchers that match the same text.
+func matcherKey(m WordMatcher) string {
+	if m.Regex != "" {
+		return "regex:" + strings.ToLower(m.Regex)
+	}
+	return "word:" + strings.ToLower(m.Word)
+}
+
+// importEntry is a single entry of an imported list, it's skipped with the reason if it could not be converted.
+type importEntry struct {
+	line           int
+	text           string
+	matchers       []WordMatcher
+	falsePositives []string
+	reason         string
+}
+
+func readTextList(r io.Reader) ([]importEntry, error) {
+	var entries []importEntry
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		entries = append(entries, wordEntry(line, text, 0, ""))
+	}
+	return entries, scanner.Err()
+}
+
+func wordEntry(line int, word string, level int32, category string) importEntry {
+	entry := importEntry{line: line, text: word}
+	word = strings.ToLower(strings.TrimSpace(word))
+	if word == "" {
+		entry.reason = "empty word"
+		return entry
+	}
+	entry.matchers = []WordMatcher{literalMatcher(word, level, category)}
+	return entry
+}
+
+// literalMatcher returns the matcher of an imported word. Words are compiled as regexes, so a word with regex
+// metacharacters ("b(tch") is matched literally by an escaped regex instead.
+func literalMatcher(word string, level int32, category string) WordMatcher {
+	if quoted := regexp.QuoteMeta(word); quoted != word {
+		return WordMatcher{Word: word, Regex: quoted, Level: level, Category: category}
+	}
+	return WordMatcher{Word: word, Level: level, Category: category}
+}
+
+func readCSVList(r io.Reader) ([]importEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	wordColumn, severityColumn, categoryColumn := -1, -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "word", "term", "phrase", "text", "profanity":
+			wordColumn = i
+		case "severity", "level", "rating", "severity_rating":
+			severityColumn = i
+		case "category", "tag", "type":
+			categoryColumn = i
+		}
+	}
+	if wordColumn >= 0 {
+		records, lines = records[1:], lines[1:]
+	} else {
+		wordColumn, severityColumn, categoryColumn = 0, 1, -1
+	}
+	var entries []importEntry
+	for i, record := range records {
+		line := lines[i]
+		text := strings.Join(record, ",")
+		if wordColumn >= len(record) {
+			entries = append(entries, importEntry{line: line, text: text, reason: "missing word column"})
+			continue
+		}
+		var level int32
+		if severityColumn >= 0 && severityColumn < len(record) && strings.TrimSpace(record[severityColumn]) != "" {
+			parsed, ok := parseSeverity(record[severityColumn])
+			if !ok {
+				entries = append(entries, importEntry{line: line, text: text, reason: fmt.Sprintf("unknown severity %q", record[severityColumn])})
+				continue
+			}
+			level = parsed
+		}
+		category := ""
+		if categoryColumn >= 0 && categoryColumn < len(record) {
+			category = strings.TrimSpace(record[categoryColumn])
+		}
+		entry := wordEntry(line, record[wordColumn], level, category)
+		entry.text = text
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseSeverity converts a numeric or descriptive severity to a level.
+func parseSeverity(severity string) (int32, bool) {
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if level, err := strconv.ParseFloat(severity, 64); err == nil && level >= 0 {
+		return int32(level + 0.5), true
+	}
+	switch severity {
+	case "mild", "low", "minor":
+		return 1, true
+	case "medium", "moderate", "strong":
+		return 2, true
+	case "high", "severe", "strongest", "extreme":
+		return 3, true
+	}
+	return 0, false
+}
+
+type jsonListEntry struct {
+	Word       string      `json:"word"`
+	Term       string      `json:"term"`
+	Match      string      `json:"match"`
+	Severity   interface{} `json:"severity"`
+	Level      interface{} `json:"level"`
+	Category   string      `json:"category"`
+	Tags       []string    `json:"tags"`
+	Exceptions []string    `json:"exceptions"`
+}
+
+func readJSONList(r io.Reader) ([]importEntry, error) {
+	var document interface{}
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, err
+	}
+	if object, ok := document.(map[string]interface{}); ok {
+		words, ok := object["words"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("goclean: JSON word list must be an array or contain a \"words\" array")
+		}
+		document = words
+	}
+	list, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("goclean: JSON word list must be an array or contain a \"words\" array")
+	}
+	entries := make([]importEntry, 0, len(list))
+	for i, item := range list {
+		entries = append(entries, jsonEntry(i+1, item))
+	}
+	return entries, nil
+}
+
+func jsonEntry(index int, item interface{}) importEntry {
+	if word, ok := item.(string); ok {
+		return wordEntry(index, word, 0, "")
+	}
+	raw, _ := json.Marshal(item)
+	entry := importEntry{line: index, text: string(raw)}
+	var object jsonListEntry
+	if _, ok := item.(map[string]interface{}); !ok || json.Unmarshal(raw, &object) != nil {
+		entry.reason = "entry must be a string or an object"
+		return entry
+	}
+	var level int32
+	for _, severity := range []interface{}{object.Severity, object.Level} {
+		if severity == nil {
+			continue
+		}
+		parsed, ok := parseSeverity(fmt.Sprint(severity))
+		if !ok {
+			entry.reason = fmt.Sprintf("unknown severity %v", severity)
+			return entry
+		}
+		level = parsed
+		break
+	}
+	category := object.Category
+	if category == "" && len(object.Tags) > 0 {
+		category = object.Tags[0]
+	}
+	var alternatives []string
+	switch {
+	case object.Word != "":
+		alternatives = []string{object.Word}
+	case object.Term != "":
+		alternatives = []string{object.Term}
+	case object.Match != "":
+		alternatives = strings.Split(object.Match, "|")
+	default:
+		entry.reason = "entry has no word, term or match"
+		return entry
+	}
+	for _, alternative := range alternatives {
+		alternative = strings.ToLower(strings.TrimSpace(alternative))
+		if alternative == "" {
+			continue
+		}
+		if strings.Contains(alternative, "*") {
+			entry.matchers = append(entry.matchers, WordMatcher{Regex: wildcardRegex(alternative), Level: level, Category: category})
+		} else {
+			entry.matchers = append(entry.matchers, literalMatcher(alternative, level, category))
+		}
+	}
+	for _, exception := range object.Exceptions {
+		if exception = strings.ToLower(strings.TrimSpace(exception)); exception != "" {
+			entry.falsePositives = append(entry.falsePositives, wildcardRegex(exception))
+		}
+	}
+	return entry
+}
+
+// wildcardRegex converts a pattern where "*" stands for any letters to a regex.
+func wildcardRegex(pattern string) string {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, `\pL*`)
+}
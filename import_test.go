@@ -0,0 +1,96 @@
+package goclean
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name                    string
+		file                    string
+		format                  ImportFormat
+		wantAdded               []WordMatcher
+		wantAddedFalsePositives []string
+		wantDuplicates          []string
+		wantSkippedLines        []int
+	}{
+		{
+			name:           "ldnoobw",
+			file:           "testdata/import/ldnoobw.txt",
+			format:         ImportText,
+			wantAdded:      []WordMatcher{{Word: "2g1c"}, {Word: "acrotomophilia"}, {Word: "b(tch", Regex: `b\(tch`}},
+			wantDuplicates: []string{"ass", "bastard"},
+		},
+		{
+			name:   "csv",
+			file:   "testdata/import/words.csv",
+			format: ImportCSV,
+			wantAdded: []WordMatcher{
+				{Word: "heck", Level: 1, Category: "mild"},
+				{Word: "darn", Level: 2, Category: "mild"},
+			},
+			wantDuplicates:   []string{"shit"},
+			wantSkippedLines: []int{5},
+		},
+		{
+			name:   "profanity list json",
+			file:   "testdata/import/profanity-list.json",
+			format: ImportJSON,
+			wantAdded: []WordMatcher{
+				{Regex: `a\pL*hole`, Level: 2, Category: "general"},
+				{Word: "heck", Level: 1, Category: "mild"},
+				{Word: "darn"},
+			},
+			wantAddedFalsePositives: []string{`\pL*bass\pL*`},
+			wantDuplicates:          []string{"ass"},
+			wantSkippedLines:        []int{4, 5},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			c := DefaultConfig()
+			profanities := len(c.Profanities)
+			report, err := Import(c, file, test.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(report.Added, test.wantAdded) {
+				t.Errorf("added: got %v, want %v", report.Added, test.wantAdded)
+			}
+			if !reflect.DeepEqual(report.AddedFalsePositives, test.wantAddedFalsePositives) {
+				t.Errorf("added false positives: got %v, want %v", report.AddedFalsePositives, test.wantAddedFalsePositives)
+			}
+			if !reflect.DeepEqual(report.Duplicates, test.wantDuplicates) {
+				t.Errorf("duplicates: got %v, want %v", report.Duplicates, test.wantDuplicates)
+			}
+			var skippedLines []int
+			for _, issue := range report.Skipped {
+				skippedLines = append(skippedLines, issue.Line)
+			}
+			if !reflect.DeepEqual(skippedLines, test.wantSkippedLines) {
+				t.Errorf("skipped: got %v, want lines %v", report.Skipped, test.wantSkippedLines)
+			}
+			if len(c.Profanities) != profanities+len(test.wantAdded) {
+				t.Errorf("got %d profanities, want %d", len(c.Profanities), profanities+len(test.wantAdded))
+			}
+		})
+	}
+}
+
+func TestParseImportFormat(t *testing.T) {
+	for name, want := range map[string]ImportFormat{"ldnoobw": ImportText, "CSV": ImportCSV, "json": ImportJSON} {
+		if got, err := ParseImportFormat(name); err != nil || got != want {
+			t.Errorf("%s: got %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseImportFormat("xml"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
@@ -0,0 +1,325 @@
+package goclean
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONOptions selects the strings of a JSON document checked by ListJSON and RedactJSON.
+//
+// Paths are JSON Pointers (RFC 6901), e.g. "/comments/0/text". In patterns "*" matches any single segment and "**"
+// matches any number of segments. A pattern also matches everything below the path it matches, so "/comments"
+// selects all strings of the comments.
+type JSONOptions struct {
+	// Include are the patterns of the checked strings, all strings are checked if it's empty.
+	Include []string
+	// Exclude are the patterns of strings that are not checked, even if they are included.
+	Exclude []string
+	// Keys enables checking the keys of objects, the path of a key is the path of its value.
+	Keys bool
+}
+
+// JSONConcern is a concern detected in a string of a JSON document.
+type JSONConcern struct {
+	DetectedConcern
+	// Path is the JSON Pointer of the string. Like the indexes returned by List, the indexes of the concern
+	// refer to the string value after JSON unescaping and sanitization, not to the raw value or the document.
+	Path string
+	// Key is true if the concern was detected in the key of an object member.
+	Key bool
+}
+
+// ListJSON reads a JSON document, or a stream of documents, and returns the concerns detected in its strings.
+func (gc *ProfanitySanitizer) ListJSON(r io.Reader, opts JSONOptions) ([]JSONConcern, error) {
+	walker, err := newJSONWalker(gc, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := walker.walk(r); err != nil {
+		return nil, err
+	}
+	return walker.concerns, nil
+}
+
+// RedactJSON reads a JSON document, or a stream of documents, and writes it to w with the selected strings redacted.
+// The document is streamed token by token, so the output is compact.
+func (gc *ProfanitySanitizer) RedactJSON(r io.Reader, w io.Writer, opts JSONOptions) error {
+	out := bufio.NewWriter(w)
+	walker, err := newJSONWalker(gc, opts, out)
+	if err != nil {
+		return err
+	}
+	if err := walker.walk(r); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+type jsonFrame struct {
+	object bool
+	count  int
+	// key is the key of the current member of an object, expectKey is true until it's read.
+	key       string
+	expectKey bool
+}
+
+type jsonWalker struct {
+	sanitizer *ProfanitySanitizer
+	opts      JSONOptions
+	include   [][]string
+	exclude   [][]string
+	out       *bufio.Writer
+	stack     []jsonFrame
+	concerns  []JSONConcern
+	encoded   bytes.Buffer
+	encoder   *json.Encoder
+}
+
+func newJSONWalker(gc *ProfanitySanitizer, opts JSONOptions, out *bufio.Writer) (*jsonWalker, error) {
+	w := &jsonWalker{sanitizer: gc, opts: opts, out: out}
+	var err error
+	if w.include, err = parsePointerPatterns(opts.Include); err != nil {
+		return nil, err
+	}
+	if w.exclude, err = parsePointerPatterns(opts.Exclude); err != nil {
+		return nil, err
+	}
+	w.encoder = json.NewEncoder(&w.encoded)
+	w.encoder.SetEscapeHTML(false)
+	return w, nil
+}
+
+func (w *jsonWalker) walk(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			if len(w.stack) > 0 {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case json.Delim:
+			if token == '{' || token == '[' {
+				w.beginValue()
+				w.writeString(token.String())
+				w.stack = append(w.stack, jsonFrame{object: token == '{', expectKey: token == '{'})
+				continue
+			}
+			w.stack = w.stack[:len(w.stack)-1]
+			w.writeString(token.String())
+			w.endValue()
+		case string:
+			if n := len(w.stack); n > 0 && w.stack[n-1].expectKey {
+				w.key(token)
+				continue
+			}
+			w.beginValue()
+			w.value(token)
+			w.endValue()
+		case nil:
+			w.beginValue()
+			w.writeString("null")
+			w.endValue()
+		default:
+			w.beginValue()
+			w.writeString(formatJSONScalar(token))
+			w.endValue()
+		}
+	}
+}
+
+func formatJSONScalar(token json.Token) string {
+	switch token := token.(type) {
+	case bool:
+		return strconv.FormatBool(token)
+	case json.Number:
+		return token.String()
+	}
+	return ""
+}
+
+// beginValue writes the separator before an array element.
+func (w *jsonWalker) beginValue() {
+	if n := len(w.stack); n > 0 && !w.stack[n-1].object && w.stack[n-1].count > 0 {
+		w.writeString(",")
+	}
+}
+
+// endValue counts the value in its container, top level values are separated by new lines.
+func (w *jsonWalker) endValue() {
+	n := len(w.stack)
+	if n == 0 {
+		w.writeString("\n")
+		return
+	}
+	w.stack[n-1].count++
+	w.stack[n-1].expectKey = w.stack[n-1].object
+}
+
+func (w *jsonWalker) key(key string) {
+	frame := &w.stack[len(w.stack)-1]
+	if frame.count > 0 {
+		w.writeString(",")
+	}
+	frame.key = key
+	frame.expectKey = false
+	if w.opts.Keys {
+		key = w.check(key, true)
+	}
+	w.writeJSONString(key)
+	w.writeString(":")
+}
+
+func (w *jsonWalker) value(value string) {
+	w.writeJSONString(w.check(value, false))
+}
+
+// check lists the concerns of a selected string and returns the string to write.
+func (w *jsonWalker) check(value string, key bool) string {
+	path := w.path()
+	if !w.selected(path) {
+		return value
+	}
+	sanitized := sanitizeString(value, w.sanitizer.config.InvalidUTF8)
+	concerns := w.sanitizer.List(sanitized)
+	if len(concerns) == 0 {
+		return value
+	}
+	pointer := formatPointer(path)
+	for _, concern := range concerns {
+		w.concerns = append(w.concerns, JSONConcern{DetectedConcern: concern, Path: pointer, Key: key})
+	}
+	if w.out == nil {
+		return value
+	}
+	return w.sanitizer.redactConcerns(sanitized, concerns)
+}
+
+func (w *jsonWalker) selected(path []string) bool {
+	if len(w.include) > 0 && !matchesAnyPattern(w.include, path) {
+		return false
+	}
+	return !matchesAnyPattern(w.exclude, path)
+}
+
+// path returns the segments of the path of the current value.
+func (w *jsonWalker) path() []string {
+	path := make([]string, len(w.stack))
+	for i, frame := range w.stack {
+		if frame.object {
+			path[i] = frame.key
+		} else {
+			path[i] = strconv.Itoa(frame.count)
+		}
+	}
+	return path
+}
+
+func (w *jsonWalker) writeString(s string) {
+	if w.out != nil {
+		w.out.WriteString(s)
+	}
+}
+
+func (w *jsonWalker) writeJSONString(s string) {
+	if w.out == nil {
+		return
+	}
+	w.encoded.Reset()
+	_ = w.encoder.Encode(s)
+	w.out.Write(bytes.TrimSuffix(w.encoded.Bytes(), []byte("\n")))
+}
+
+func parsePointerPatterns(patterns []string) ([][]string, error) {
+	parsed := make([][]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		segments, err := parsePointer(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, segments)
+	}
+	return parsed, nil
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// parsePointer splits a JSON Pointer into its unescaped segments.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("goclean: JSON pointer %q must start with /", pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segments[i] = pointerUnescaper.Replace(segment)
+	}
+	return segments, nil
+}
+
+func formatPointer(path []string) string {
+	var pointer strings.Builder
+	for _, segment := range path {
+		pointer.WriteString("/")
+		pointer.WriteString(pointerEscaper.Replace(segment))
+	}
+	return pointer.String()
+}
+
+func matchesAnyPattern(patterns [][]string, path []string) bool {
+	for _, pattern := range patterns {
+		if matchesPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern reports whether the pattern matches the path or one of its ancestors.
+func matchesPattern(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchesPattern(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || pattern[0] != "*" && pattern[0] != path[0] {
+		return false
+	}
+	return matchesPattern(pattern[1:], path[1:])
+}
+
+// ListJSON reads a JSON document and returns the concerns detected in its strings.
+//
+// Uses the default ProfanitySanitizer
+func ListJSON(r io.Reader, opts JSONOptions) ([]JSONConcern, error) {
+	return gc.ListJSON(r, opts)
+}
+
+// RedactJSON reads a JSON document and writes it to w with the selected strings redacted.
+//
+// Uses the default ProfanitySanitizer
+func RedactJSON(r io.Reader, w io.Writer, opts JSONOptions) error {
+	return gc.RedactJSON(r, w, opts)
+}
@@ -0,0 +1,91 @@
+package goclean
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testJSONDocument = `{
+  "user": {"name": "fûçk", "bio": "<b>shit</b> happens", "age": 42, "verified": true},
+  "comments": [{"text": "hello", "id": 1}, {"text": "a.s.s", "id": 2.5e3}],
+  "a/b~c": ["crap", null],
+  "shit": "clean"
+}`
+
+func TestGoClean_RedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		opts JSONOptions
+		want string
+	}{
+		{
+			"all strings",
+			JSONOptions{},
+			`{"user":{"name":"****","bio":"<b>****</b> happens","age":42,"verified":true},"comments":[{"text":"hello","id":1},{"text":"*****","id":2.5e3}],"a/b~c":["****",null],"shit":"clean"}` + "\n",
+		},
+		{
+			"keys",
+			JSONOptions{Keys: true, Include: []string{"/shit"}},
+			`{"user":{"name":"fûçk","bio":"<b>shit</b> happens","age":42,"verified":true},"comments":[{"text":"hello","id":1},{"text":"a.s.s","id":2.5e3}],"a/b~c":["crap",null],"****":"clean"}` + "\n",
+		},
+		{
+			"include with wildcard and exclude",
+			JSONOptions{Include: []string{"/comments/*/text", "/user"}, Exclude: []string{"/**/bio"}},
+			`{"user":{"name":"****","bio":"<b>shit</b> happens","age":42,"verified":true},"comments":[{"text":"hello","id":1},{"text":"*****","id":2.5e3}],"a/b~c":["crap",null],"shit":"clean"}` + "\n",
+		},
+		{
+			"escaped pointer",
+			JSONOptions{Include: []string{"/a~1b~0c/0"}},
+			`{"user":{"name":"fûçk","bio":"<b>shit</b> happens","age":42,"verified":true},"comments":[{"text":"hello","id":1},{"text":"a.s.s","id":2.5e3}],"a/b~c":["****",null],"shit":"clean"}` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := RedactJSON(strings.NewReader(testJSONDocument), &out, test.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != test.want {
+				t.Errorf("got  %s\nwant %s", out.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestGoClean_ListJSON(t *testing.T) {
+	got, err := ListJSON(strings.NewReader(testJSONDocument), JSONOptions{Keys: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var paths []string
+	for _, concern := range got {
+		paths = append(paths, concern.Path)
+	}
+	want := []string{"/user/name", "/user/bio", "/comments/1/text", "/a~1b~0c/0", "/shit"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got %v, want %v", paths, want)
+	}
+	if got[1].MatchedText != "shit" || got[1].StartIndex != 3 || got[1].Key || !got[4].Key {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestGoClean_RedactJSONStream(t *testing.T) {
+	var out bytes.Buffer
+	if err := RedactJSON(strings.NewReader("\"shit\"\n[1, \"ass\"]\n"), &out, JSONOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "\"****\"\n[1,\"***\"]\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+	for _, invalid := range []string{`{"a": "shit"`, `{"a" "b"}`, `[1,]`} {
+		if err := RedactJSON(strings.NewReader(invalid), &out, JSONOptions{}); err == nil {
+			t.Errorf("expected error for %s", invalid)
+		}
+	}
+	if _, err := ListJSON(strings.NewReader("{}"), JSONOptions{Include: []string{"comments"}}); err == nil {
+		t.Errorf("expected error for invalid pointer")
+	}
+}
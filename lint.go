@@ -0,0 +1,257 @@
+package goclean
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LintSeverity is the severity of a LintIssue.
+type LintSeverity int
+
+const (
+	// LintWarning is an entry that is redundant or likely a mistake.
+	LintWarning LintSeverity = iota
+	// LintError is an entry that breaks detection.
+	LintError
+)
+
+func (s LintSeverity) String() string {
+	if s == LintError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Codes of the issues reported by Lint.
+const (
+	LintInvalidRegex          = "invalid-regex"
+	LintEmptyEntry            = "empty-entry"
+	LintDuplicate             = "duplicate"
+	LintShadowed              = "shadowed"
+	LintMatchesEmpty          = "matches-empty"
+	LintTooBroad              = "too-broad"
+	LintUnusedFalsePositive   = "unused-false-positive"
+	LintUnneededFalseNegative = "unneeded-false-negative"
+)
+
+// LintIssue is a problem found in a Config by Lint.
+type LintIssue struct {
+	Severity LintSeverity
+	// Code identifies the kind of the issue, e.g. LintDuplicate.
+	Code string
+	// Entry describes the entry with the issue, e.g. `profanities[35] "fuck"`.
+	Entry   string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", i.Severity, i.Entry, i.Message, i.Code)
+}
+
+// broadSamples are ordinary texts, a matcher that matches a single character or most of the words is too broad.
+var broadSamples = strings.Fields("the and you that have for not with this but his from they say her she will one all would there their what")
+
+// Lint checks the dictionaries of the Config for conflicts and dangerous patterns:
+//   - regexes that can not be compiled, match the empty string or are catastrophically broad
+//   - duplicate entries and words that can never be reported, as they are covered by another entry
+//   - false positives that do not contain any profanity
+//   - false negatives that are not suppressed by any false positive
+func Lint(c *Config) []LintIssue {
+	l := &linter{config: c}
+	profanities := l.compile("profanities", c.Profanities)
+	falseNegatives := l.compile("falseNegatives", c.FalseNegatives)
+	falsePositives := l.compileFalsePositives()
+
+	l.checkMatchers(profanities)
+	l.checkMatchers(falseNegatives)
+	l.checkFalsePositives(falsePositives, append(append([]lintMatcher(nil), falseNegatives...), profanities...))
+	l.checkFalseNegatives(falseNegatives, falsePositives)
+	return l.issues
+}
+
+type linter struct {
+	config *Config
+	issues []LintIssue
+}
+
+type lintMatcher struct {
+	entry   string
+	matcher WordMatcher
+	regexp  *regexp.Regexp
+}
+
+type lintFalsePositive struct {
+	entry  string
+	regex  string
+	regexp *regexp.Regexp
+}
+
+func (l *linter) report(severity LintSeverity, code, entry, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{Severity: severity, Code: code, Entry: entry, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) compile(list string, matchers []WordMatcher) []lintMatcher {
+	compiled := make([]lintMatcher, 0, len(matchers))
+	for i, m := range matchers {
+		entry := fmt.Sprintf("%s[%d] %q", list, i, m.Word)
+		if m.Regex != "" {
+			entry = fmt.Sprintf("%s[%d] %q", list, i, m.Regex)
+		}
+		re, err := l.config.compileMatcher(m)
+		switch {
+		case err != nil:
+			l.report(LintError, LintInvalidRegex, entry, "%v", err)
+		case re == nil:
+			l.report(LintWarning, LintEmptyEntry, entry, "entry has neither a word nor a regex")
+		default:
+			compiled = append(compiled, lintMatcher{entry: entry, matcher: m, regexp: re})
+		}
+	}
+	return compiled
+}
+
+func (l *linter) compileFalsePositives() []lintFalsePositive {
+	compiled := make([]lintFalsePositive, 0, len(l.config.FalsePositives))
+	seen := make(map[string]string)
+	for i, fp := range l.config.FalsePositives {
+		entry := fmt.Sprintf("falsePositives[%d] %q", i, fp)
+		if fp == "" {
+			l.report(LintWarning, LintEmptyEntry, entry, "false positive is empty")
+			continue
+		}
+		re, err := regexp.Compile(fp)
+		if err != nil {
+			l.report(LintError, LintInvalidRegex, entry, "%v", err)
+			continue
+		}
+		if previous, ok := seen[fp]; ok {
+			l.report(LintWarning, LintDuplicate, entry, "duplicate of %s", previous)
+			continue
+		}
+		seen[fp] = entry
+		if re.MatchString("") {
+			l.report(LintError, LintMatchesEmpty, entry, "false positive matches the empty string and suppresses everything")
+			continue
+		}
+		compiled = append(compiled, lintFalsePositive{entry: entry, regex: fp, regexp: re})
+	}
+	return compiled
+}
+
+func (l *linter) checkMatchers(matchers []lintMatcher) {
+	seen := make(map[string]string)
+	for i, m := range matchers {
+		if m.regexp.MatchString("") {
+			l.report(LintError, LintMatchesEmpty, m.entry, "matcher matches the empty string")
+			continue
+		}
+		if matches, ok := isTooBroad(m.regexp); ok {
+			l.report(LintError, LintTooBroad, m.entry, "matcher is too broad, it matches %s", matches)
+			continue
+		}
+		key := matcherKey(m.matcher)
+		if previous, ok := seen[key]; ok {
+			l.report(LintWarning, LintDuplicate, m.entry, "duplicate of %s", previous)
+			continue
+		}
+		seen[key] = m.entry
+		if m.matcher.Regex != "" {
+			continue
+		}
+		if other, ok := coveringRegex(m, matchers); ok {
+			l.report(LintWarning, LintShadowed, m.entry, "word is already matched by the regex of %s", other.entry)
+			continue
+		}
+		for _, earlier := range matchers[:i] {
+			if earlier.matcher.Regex == "" && earlier.regexp.MatchString(m.matcher.Word) && matcherKey(earlier.matcher) != key {
+				l.report(LintWarning, LintShadowed, m.entry, "word is never reported, as the earlier %s matches it first", earlier.entry)
+				break
+			}
+		}
+	}
+}
+
+// coveringRegex returns another regex matcher that matches the whole word of m.
+func coveringRegex(m lintMatcher, matchers []lintMatcher) (lintMatcher, bool) {
+	for _, other := range matchers {
+		if other.matcher.Regex == "" {
+			continue
+		}
+		if loc := other.regexp.FindStringIndex(m.matcher.Word); loc != nil && loc[0] == 0 && loc[1] == len(m.matcher.Word) {
+			return other, true
+		}
+	}
+	return lintMatcher{}, false
+}
+
+// isTooBroad reports whether the matcher matches a single letter or most of the common words,
+// and describes what it matches.
+func isTooBroad(re *regexp.Regexp) (string, bool) {
+	for c := 'a'; c <= 'z'; c++ {
+		if re.MatchString(string(c)) {
+			return fmt.Sprintf("the letter %q", c), true
+		}
+	}
+	matched := 0
+	for _, sample := range broadSamples {
+		if re.MatchString(sample) {
+			matched++
+		}
+	}
+	if matched*2 > len(broadSamples) {
+		return fmt.Sprintf("%d of %d common words", matched, len(broadSamples)), true
+	}
+	return "", false
+}
+
+// literal returns the text matched by a regex without any special characters.
+func literal(regex string) (string, bool) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return "", false
+	}
+	prefix, complete := re.LiteralPrefix()
+	return prefix, complete && prefix != ""
+}
+
+func (l *linter) checkFalsePositives(falsePositives []lintFalsePositive, matchers []lintMatcher) {
+	for _, fp := range falsePositives {
+		text, ok := literal(fp.regex)
+		if !ok {
+			continue
+		}
+		containsProfanity := false
+		for _, m := range matchers {
+			if m.regexp.MatchString(text) {
+				containsProfanity = true
+				break
+			}
+		}
+		if !containsProfanity {
+			l.report(LintWarning, LintUnusedFalsePositive, fp.entry, "false positive does not contain any profanity")
+		}
+	}
+}
+
+func (l *linter) checkFalseNegatives(falseNegatives []lintMatcher, falsePositives []lintFalsePositive) {
+	for _, fn := range falseNegatives {
+		text := fn.matcher.Word
+		if fn.matcher.Regex != "" {
+			var ok bool
+			if text, ok = literal(fn.matcher.Regex); !ok {
+				continue
+			}
+		}
+		suppressed := false
+		for _, fp := range falsePositives {
+			if fp.regexp.MatchString(text) {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			l.report(LintWarning, LintUnneededFalseNegative, fn.entry, "false negative is not suppressed by any false positive, it can be a profanity")
+		}
+	}
+}
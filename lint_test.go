@@ -0,0 +1,97 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   []LintIssue
+	}{
+		{
+			name: "clean config",
+			config: Config{
+				Profanities:    []WordMatcher{{Word: "ass"}, {Regex: "f[u]+ck"}},
+				FalsePositives: []string{"bass"},
+				FalseNegatives: []WordMatcher{{Word: "dumbass"}},
+			},
+			want: nil,
+		},
+		{
+			name:   "invalid regex",
+			config: Config{Profanities: []WordMatcher{{Regex: "f[u+ck"}}},
+			want: []LintIssue{{Severity: LintError, Code: LintInvalidRegex, Entry: `profanities[0] "f[u+ck"`,
+				Message: "error parsing regexp: missing closing ]: `[u+ck`"}},
+		},
+		{
+			name:   "empty entry",
+			config: Config{Profanities: []WordMatcher{{Level: 2}}},
+			want:   []LintIssue{{Severity: LintWarning, Code: LintEmptyEntry, Entry: `profanities[0] ""`, Message: "entry has neither a word nor a regex"}},
+		},
+		{
+			name:   "duplicate word",
+			config: Config{Profanities: []WordMatcher{{Word: "ass"}, {Word: "ASS", Level: 2}}},
+			want:   []LintIssue{{Severity: LintWarning, Code: LintDuplicate, Entry: `profanities[1] "ASS"`, Message: `duplicate of profanities[0] "ass"`}},
+		},
+		{
+			name:   "word covered by regex",
+			config: Config{Profanities: []WordMatcher{{Word: "fuck"}, {Regex: "f[u]+ck"}}},
+			want: []LintIssue{{Severity: LintWarning, Code: LintShadowed, Entry: `profanities[0] "fuck"`,
+				Message: `word is already matched by the regex of profanities[1] "f[u]+ck"`}},
+		},
+		{
+			name:   "word shadowed by earlier word",
+			config: Config{Profanities: []WordMatcher{{Word: "balls"}, {Word: "ballsack"}}},
+			want: []LintIssue{{Severity: LintWarning, Code: LintShadowed, Entry: `profanities[1] "ballsack"`,
+				Message: `word is never reported, as the earlier profanities[0] "balls" matches it first`}},
+		},
+		{
+			name:   "regex matches empty string",
+			config: Config{Profanities: []WordMatcher{{Regex: "f?u?"}}},
+			want:   []LintIssue{{Severity: LintError, Code: LintMatchesEmpty, Entry: `profanities[0] "f?u?"`, Message: "matcher matches the empty string"}},
+		},
+		{
+			name:   "too broad regex",
+			config: Config{Profanities: []WordMatcher{{Regex: "[a-z]{3}"}}},
+			want: []LintIssue{{Severity: LintError, Code: LintTooBroad, Entry: `profanities[0] "[a-z]{3}"`,
+				Message: "matcher is too broad, it matches 23 of 23 common words"}},
+		},
+		{
+			name:   "single letter word",
+			config: Config{Profanities: []WordMatcher{{Word: "x"}}},
+			want:   []LintIssue{{Severity: LintError, Code: LintTooBroad, Entry: `profanities[0] "x"`, Message: `matcher is too broad, it matches the letter 'x'`}},
+		},
+		{
+			name:   "unused false positive",
+			config: Config{Profanities: []WordMatcher{{Word: "ass"}}, FalsePositives: []string{"bass", "shoe", "sh.e"}},
+			want: []LintIssue{{Severity: LintWarning, Code: LintUnusedFalsePositive, Entry: `falsePositives[1] "shoe"`,
+				Message: "false positive does not contain any profanity"}},
+		},
+		{
+			name:   "false positive matches empty string",
+			config: Config{FalsePositives: []string{"x*"}},
+			want: []LintIssue{{Severity: LintError, Code: LintMatchesEmpty, Entry: `falsePositives[0] "x*"`,
+				Message: "false positive matches the empty string and suppresses everything"}},
+		},
+		{
+			name:   "unneeded false negative",
+			config: Config{Profanities: []WordMatcher{{Word: "ass"}}, FalsePositives: []string{"bass"}, FalseNegatives: []WordMatcher{{Word: "asshole"}}},
+			want: []LintIssue{{Severity: LintWarning, Code: LintUnneededFalseNegative, Entry: `falseNegatives[0] "asshole"`,
+				Message: "false negative is not suppressed by any false positive, it can be a profanity"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.DetectObfuscated = true
+			test.config.DetectLeetSpeak = true
+			test.config.ObfuscationLength = 3
+			got := Lint(&test.config)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
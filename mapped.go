@@ -0,0 +1,163 @@
+package goclean
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// mappedText is the sanitized text content of a document with markup, like HTML or Markdown, that is matched
+// instead of the document. It remembers the source range of every piece of the text, so concerns can be
+// mapped back to the document and redacted without touching the markup.
+type mappedText struct {
+	policy            InvalidUTF8Policy
+	obfuscationLength int32
+	// opts are the options the text is detected with
+	opts    detectOptions
+	scratch *scratch
+	text    []byte
+	// separated is the length of the text after the last separator
+	separated int
+	clusters  []textCluster
+}
+
+// textCluster is a piece of the text that comes from the source range [sourceStart, sourceEnd).
+type textCluster struct {
+	start, end             int
+	sourceStart, sourceEnd int
+	// kept clusters are matched with the text around them, but not redacted
+	kept bool
+}
+
+func newMappedText(c *Config) *mappedText {
+	return &mappedText{policy: c.InvalidUTF8, obfuscationLength: c.ObfuscationLength, scratch: getScratch()}
+}
+
+// release returns the buffers of the mappedText to the pool.
+func (m *mappedText) release() {
+	putScratch(m.scratch)
+}
+
+// appendSource appends text found at offset in the source. Every rune is mapped separately, together with its
+// combining marks, so concerns are mapped to exact source positions.
+func (m *mappedText) appendSource(text string, offset int) {
+	for i := 0; i < len(text); {
+		end := i + runeSize(text[i:])
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !unicode.Is(unicode.Mn, r) {
+				break
+			}
+			end += size
+		}
+		m.appendDecoded(text[i:end], offset+i, offset+end)
+		i = end
+	}
+}
+
+// appendKept appends text found at offset in the source that is matched together with the text around it, but never
+// redacted, like the content of a code span. Concerns that are only made of kept text are ignored.
+func (m *mappedText) appendKept(text string, offset int) {
+	first := len(m.clusters)
+	m.appendSource(text, offset)
+	for i := first; i < len(m.clusters); i++ {
+		m.clusters[i].kept = true
+	}
+}
+
+// appendDecoded appends text that represents the whole source range, e.g. a decoded HTML entity.
+func (m *mappedText) appendDecoded(text string, sourceStart, sourceEnd int) {
+	text = m.scratch.sanitize(text, m.policy)
+	if text == "" {
+		return
+	}
+	m.clusters = append(m.clusters, textCluster{start: len(m.text), end: len(m.text) + len(text), sourceStart: sourceStart, sourceEnd: sourceEnd})
+	m.text = append(m.text, text...)
+}
+
+// appendSeparator separates words of different blocks, so they are not matched together. The separator is
+// longer than the obfuscation length, so obfuscated words are not matched across it either.
+func (m *mappedText) appendSeparator() {
+	if len(m.text) == m.separated {
+		return
+	}
+	for i := int32(0); i <= m.obfuscationLength; i++ {
+		m.text = append(m.text, '\n')
+	}
+	m.separated = len(m.text)
+}
+
+// list detects the concerns in the text. The indexes of the concerns are mapped to the source,
+// the matched text is the text content without markup.
+func (m *mappedText) list(gc *ProfanitySanitizer) []DetectedConcern {
+	text := string(m.text)
+	concerns, _ := gc.detectIn(context.Background(), make([]DetectedConcern, 0), text, &m.scratch.matched, m.opts)
+	mapped := concerns[:0]
+	for _, concern := range concerns {
+		clusters := m.clustersIn(int(concern.StartIndex), int(concern.EndIndex))
+		if len(clusters) > 0 {
+			if allKept(clusters) {
+				continue
+			}
+			concern.StartIndex = int32(clusters[0].sourceStart)
+			concern.EndIndex = int32(clusters[len(clusters)-1].sourceEnd)
+		}
+		mapped = append(mapped, concern)
+	}
+	sort.Slice(mapped, func(i, j int) bool { return mapped[i].StartIndex < mapped[j].StartIndex })
+	return mapped
+}
+
+// redact replaces the source of every cluster that is part of a concern detected in the text,
+// escape converts the replacement to the syntax of the source.
+func (m *mappedText) redact(gc *ProfanitySanitizer, source string, escape func(string) string) string {
+	text := string(m.text)
+	concerns, _ := gc.detectIn(context.Background(), make([]DetectedConcern, 0), text, &m.scratch.matched, m.opts)
+	if len(concerns) == 0 {
+		return source
+	}
+	var redacted []textCluster
+	for _, concern := range concerns {
+		redacted = append(redacted, m.clustersIn(int(concern.StartIndex), int(concern.EndIndex))...)
+	}
+	sort.Slice(redacted, func(i, j int) bool { return redacted[i].sourceStart < redacted[j].sourceStart })
+	var b strings.Builder
+	b.Grow(len(source))
+	last := 0
+	for _, cluster := range redacted {
+		if cluster.kept || cluster.sourceStart < last {
+			continue
+		}
+		b.WriteString(source[last:cluster.sourceStart])
+		b.WriteString(escape(replace(text[cluster.start:cluster.end], gc.config.ReplacementCharacter)))
+		last = cluster.sourceEnd
+	}
+	b.WriteString(source[last:])
+	return b.String()
+}
+
+// clustersIn returns the clusters that overlap the [start, end) range of the text.
+func (m *mappedText) clustersIn(start, end int) []textCluster {
+	i := sort.Search(len(m.clusters), func(i int) bool { return m.clusters[i].end > start })
+	j := i
+	for j < len(m.clusters) && m.clusters[j].start < end {
+		j++
+	}
+	return m.clusters[i:j]
+}
+
+func allKept(clusters []textCluster) bool {
+	for _, cluster := range clusters {
+		if !cluster.kept {
+			return false
+		}
+	}
+	return true
+}
+
+func runeSize(s string) int {
+	_, size := utf8.DecodeRuneInString(s)
+	return size
+}
@@ -0,0 +1,340 @@
+package goclean
+
+import (
+	"html"
+	"strings"
+)
+
+// MarkdownOptions configures ListMarkdown and RedactMarkdown.
+type MarkdownOptions struct {
+	// Code enables matching and redacting code spans and fenced code blocks. Without it, fenced code blocks
+	// are ignored and code spans are only matched together with the text around them ("`fu`ck"), the code
+	// itself is not redacted.
+	Code bool
+	// URLs enables matching and redacting link destinations, image URLs and bare URLs.
+	URLs bool
+}
+
+// ListMarkdown detects profanities in the text of a Markdown document. Inline formatting markers ("*", "_", "~"),
+// block markers, inline HTML tags and backslash escapes are stripped for matching, so "f**u**ck" is matched like "fuck".
+// Code and URLs are not redacted unless they are enabled in the options.
+//
+// StartIndex and EndIndex of the concerns are byte offsets in the document, MatchedText is the text without markup.
+func (gc *ProfanitySanitizer) ListMarkdown(document string, opts MarkdownOptions) []DetectedConcern {
+	m := gc.mapMarkdown(document, opts)
+	defer m.release()
+	return m.list(gc)
+}
+
+// RedactMarkdown redacts profanities in the text of a Markdown document, like ListMarkdown detects them.
+// The formatting is written back as it is, replacement characters that are Markdown syntax are escaped.
+func (gc *ProfanitySanitizer) RedactMarkdown(document string, opts MarkdownOptions) string {
+	m := gc.mapMarkdown(document, opts)
+	defer m.release()
+	return m.redact(gc, document, escapeMarkdown)
+}
+
+type markdownMapper struct {
+	m        *mappedText
+	document string
+	opts     MarkdownOptions
+	// skip contains the ends of the link destinations and reference labels by their start
+	skip map[int]int
+}
+
+func (gc *ProfanitySanitizer) mapMarkdown(document string, opts MarkdownOptions) *mappedText {
+	p := &markdownMapper{m: newMappedText(&gc.config), document: document, opts: opts, skip: make(map[int]int)}
+	fence := ""
+	for start := 0; start < len(document); {
+		end := strings.IndexByte(document[start:], '\n') + 1
+		if end == 0 {
+			end = len(document) - start
+		}
+		end += start
+		line := document[start:end]
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+				p.m.appendSeparator()
+			} else if opts.Code {
+				p.m.appendSource(line, start)
+			}
+		case len(line)-len(trimmed) < 4 && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+			p.m.appendSeparator()
+		case strings.TrimSpace(line) == "":
+			p.m.appendSeparator()
+		default:
+			p.line(start, end)
+		}
+		start = end
+	}
+	return p.m
+}
+
+// line maps a line of a paragraph, heading, list item or quote.
+func (p *markdownMapper) line(start, end int) {
+	i := p.skipBlockMarkers(start, end)
+	if i > start {
+		p.m.appendSeparator()
+	}
+	if isLinkReferenceDefinition(p.document[i:end]) && !p.opts.URLs {
+		return
+	}
+	p.inline(i, end)
+}
+
+// skipBlockMarkers returns the index after the indentation, quote, heading and list markers of the line.
+func (p *markdownMapper) skipBlockMarkers(i, end int) int {
+	doc := p.document
+	for {
+		j := i
+		for j < end && j-i < 3 && doc[j] == ' ' {
+			j++
+		}
+		switch {
+		case j < end && doc[j] == '>':
+			i = j + 1
+		case j < end && doc[j] == '#':
+			k := j
+			for k < end && doc[k] == '#' {
+				k++
+			}
+			if k-j > 6 || k < end && doc[k] != ' ' && doc[k] != '\n' {
+				return i
+			}
+			return k
+		case j+1 < end && strings.IndexByte("-*+", doc[j]) >= 0 && doc[j+1] == ' ':
+			i = j + 2
+		default:
+			k := j
+			for k < end && doc[k] >= '0' && doc[k] <= '9' && k-j < 9 {
+				k++
+			}
+			if k > j && k+1 < end && (doc[k] == '.' || doc[k] == ')') && doc[k+1] == ' ' {
+				i = k + 2
+				continue
+			}
+			return i
+		}
+	}
+}
+
+// inline maps the inline content of a line.
+func (p *markdownMapper) inline(i, end int) {
+	doc := p.document
+	for i < end {
+		if skipEnd, ok := p.skip[i]; ok {
+			if p.opts.URLs {
+				p.m.appendSource(doc[i:skipEnd], i)
+			}
+			i = skipEnd
+			continue
+		}
+		switch c := doc[i]; {
+		case c == '*' || c == '_' || c == '~':
+			i++
+		case c == '\\' && i+1 < end && isASCIIPunct(doc[i+1]):
+			p.m.appendDecoded(doc[i+1:i+2], i, i+2)
+			i += 2
+		case c == '`':
+			i = p.codeSpan(i, end)
+		case c == '!' && i+1 < end && doc[i+1] == '[':
+			i++
+		case c == '[':
+			p.link(i, end)
+			i++
+		case c == '<':
+			if j, ok := scanAutolink(doc, i, end); ok {
+				if p.opts.URLs {
+					p.m.appendSource(doc[i+1:j-1], i+1)
+				}
+				i = j
+			} else if j, _, _, ok := scanHTMLTag(doc, i); ok && j <= end {
+				i = j
+			} else {
+				p.m.appendSource("<", i)
+				i++
+			}
+		case c == '&':
+			j := scanHTMLEntity(doc, i)
+			if decoded := html.UnescapeString(doc[i:j]); j > i+1 && j <= end && decoded != doc[i:j] {
+				p.m.appendDecoded(decoded, i, j)
+				i = j
+			} else {
+				p.m.appendSource("&", i)
+				i++
+			}
+		case (c == 'h' || c == 'H') && isURLStart(doc[i:end]):
+			j := i + strings.IndexAny(doc[i:end]+" ", " \t\n")
+			if p.opts.URLs {
+				p.m.appendSource(doc[i:j], i)
+			}
+			i = j
+		default:
+			j := i + 1
+			for j < end && strings.IndexByte("*_~\\`![<&hH", doc[j]) < 0 {
+				j++
+			}
+			if skipEnd, ok := p.nextSkip(i, j); ok {
+				j = skipEnd
+			}
+			p.m.appendSource(doc[i:j], i)
+			i = j
+		}
+	}
+}
+
+// nextSkip returns the start of a skipped region in [i, j).
+func (p *markdownMapper) nextSkip(i, j int) (int, bool) {
+	for k := i + 1; k < j; k++ {
+		if _, ok := p.skip[k]; ok {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// codeSpan maps the code span starting at i and returns the index after it.
+// A backtick run without a closing run of the same length is text.
+func (p *markdownMapper) codeSpan(i, end int) int {
+	doc := p.document
+	j := i
+	for j < end && doc[j] == '`' {
+		j++
+	}
+	run := doc[i:j]
+	for k := j; k < end; {
+		next := strings.Index(doc[k:end], run)
+		if next < 0 {
+			break
+		}
+		k += next
+		closing := k + len(run)
+		if closing < end && doc[closing] == '`' {
+			for closing < end && doc[closing] == '`' {
+				closing++
+			}
+			k = closing
+			continue
+		}
+		if p.opts.Code {
+			p.m.appendSource(doc[j:k], j)
+		} else {
+			p.m.appendKept(doc[j:k], j)
+		}
+		return closing
+	}
+	p.m.appendSource(run, i)
+	return j
+}
+
+// link records the destination or reference label of the link whose text starts at i, so it's skipped.
+func (p *markdownMapper) link(i, end int) {
+	doc := p.document
+	depth := 0
+	for j := i; j < end; j++ {
+		switch doc[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			switch {
+			case j+1 < end && doc[j+1] == '(':
+				if k := matchingParen(doc, j+1, end); k > 0 {
+					p.skip[j+1] = k
+				}
+			case j+1 < end && doc[j+1] == '[':
+				if k := strings.IndexByte(doc[j+1:end], ']'); k > 0 {
+					p.skip[j+1] = j + 1 + k + 1
+				}
+			}
+			return
+		}
+	}
+}
+
+// matchingParen returns the index after the parenthesis closing the one at i, or 0.
+func matchingParen(doc string, i, end int) int {
+	depth := 0
+	for j := i; j < end; j++ {
+		switch doc[j] {
+		case '\\':
+			j++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return 0
+}
+
+// scanAutolink returns the index after an autolink like <https://example.com> starting at i.
+func scanAutolink(doc string, i, end int) (int, bool) {
+	j := strings.IndexByte(doc[i:end], '>')
+	if j < 0 {
+		return 0, false
+	}
+	content := doc[i+1 : i+j]
+	if strings.ContainsAny(content, " <") || !strings.Contains(content, ":") && !strings.Contains(content, "@") {
+		return 0, false
+	}
+	return i + j + 1, true
+}
+
+func isURLStart(s string) bool {
+	return len(s) > 7 && strings.EqualFold(s[:7], "http://") || len(s) > 8 && strings.EqualFold(s[:8], "https://")
+}
+
+// isLinkReferenceDefinition reports whether the line defines a link reference, like "[id]: https://example.com".
+func isLinkReferenceDefinition(line string) bool {
+	if !strings.HasPrefix(line, "[") {
+		return false
+	}
+	end := strings.Index(line, "]:")
+	return end > 1 && !strings.Contains(line[:end], "]")
+}
+
+func isASCIIPunct(c byte) bool {
+	return c > ' ' && c < 0x7f && !isASCIILetter(c) && (c < '0' || c > '9')
+}
+
+// escapeMarkdown escapes the characters of a replacement that are Markdown syntax.
+func escapeMarkdown(s string) string {
+	if !strings.ContainsAny(s, "\\`*_{}[]()#+-.!<>~|") {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x80 && strings.ContainsRune("\\`*_{}[]()#+-.!<>~|", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ListMarkdown detects profanities in the text of a Markdown document.
+//
+// Uses the default ProfanitySanitizer
+func ListMarkdown(document string, opts MarkdownOptions) []DetectedConcern {
+	return gc.ListMarkdown(document, opts)
+}
+
+// RedactMarkdown redacts profanities in the text of a Markdown document.
+//
+// Uses the default ProfanitySanitizer
+func RedactMarkdown(document string, opts MarkdownOptions) string {
+	return gc.RedactMarkdown(document, opts)
+}
@@ -0,0 +1,55 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_RedactMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		opts     MarkdownOptions
+		want     string
+	}{
+		{"text", "what the fuck", MarkdownOptions{}, `what the \*\*\*\*`},
+		{"emphasis markers", "f**u**ck and _sh_it", MarkdownOptions{}, `\***\***\*\* and _\*\*_\*\*`},
+		{"strikethrough", "a~~ss~~", MarkdownOptions{}, `\*~~\*\*~~`},
+		{"escaped markers are text", `sh\*it \[ass\]`, MarkdownOptions{}, `\*\*\*\*\* \[\*\*\*\]`},
+		{"heading and list", "# shit\n- ass\n1. crap", MarkdownOptions{}, "# \\*\\*\\*\\*\n- \\*\\*\\*\n1. \\*\\*\\*\\*"},
+		{"blocks separate words", "a\n\nss\n- sh\n- it", MarkdownOptions{}, "a\n\nss\n- sh\n- it"},
+		{"code spans are kept", "`fu`ck `shit`", MarkdownOptions{}, "`fu`\\*\\* `shit`"},
+		{"code spans are ignored on their own", "`shit` ass`hole`", MarkdownOptions{}, "`shit` \\*\\*\\*`hole`"},
+		{"code spans", "`fu`ck", MarkdownOptions{Code: true}, "`\\*\\*`\\*\\*"},
+		{"fenced code is kept", "```\nshit\n```\nshit", MarkdownOptions{}, "```\nshit\n```\n\\*\\*\\*\\*"},
+		{"fenced code", "~~~go\nshit\n~~~", MarkdownOptions{Code: true}, "~~~go\n\\*\\*\\*\\*\n~~~"},
+		{"link destinations are kept", "[shit](https://example.com/ass \"fuck\") ![ass](/crap.png)", MarkdownOptions{}, "[\\*\\*\\*\\*](https://example.com/ass \"fuck\") ![\\*\\*\\*](/crap.png)"},
+		{"link destinations", "[hello](/shit)", MarkdownOptions{URLs: true}, "[hello](/\\*\\*\\*\\*)"},
+		{"reference links", "[shit][ass]\n\n[ass]: https://example.com/fuck", MarkdownOptions{}, "[\\*\\*\\*\\*][ass]\n\n[ass]: https://example.com/fuck"},
+		{"bare URLs and autolinks are kept", "see https://example.com/shit and <https://ass.com> shit", MarkdownOptions{}, "see https://example.com/shit and <https://ass.com> \\*\\*\\*\\*"},
+		{"inline HTML", "a<b>ss</b> f&uuml;ck", MarkdownOptions{}, "\\*<b>\\*\\*</b> \\*\\*\\*\\*"},
+		{"quote", "> shit", MarkdownOptions{}, "> \\*\\*\\*\\*"},
+		{"false positives", "**bass**", MarkdownOptions{}, "**bass**"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RedactMarkdown(test.document, test.opts); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGoClean_ListMarkdown(t *testing.T) {
+	got := ListMarkdown("[link](/ass) f**u**ck", MarkdownOptions{})
+	want := []DetectedConcern{{MatchedText: "fuck", StartIndex: 13, EndIndex: 21}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got = ListMarkdown("`fu`ck `shit`", MarkdownOptions{})
+	want = []DetectedConcern{{MatchedText: "fuck", StartIndex: 1, EndIndex: 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
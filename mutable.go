@@ -0,0 +1,174 @@
+package goclean
+
+import (
+	"io"
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// MutableSanitizer is a ProfanitySanitizer whose dictionaries can be changed at runtime.
+//
+// Changes are copy-on-write: every change compiles only the added entry and publishes a new
+// snapshot, so readers are never blocked and always see a consistent dictionary.
+// It is safe for concurrent use.
+type MutableSanitizer struct {
+	mu      sync.Mutex
+	current atomic.Value
+}
+
+// NewMutableSanitizer creates a new MutableSanitizer with the provided Config.
+func NewMutableSanitizer(c *Config) *MutableSanitizer {
+	sanitizer := NewProfanitySanitizer(c)
+	m := &MutableSanitizer{}
+	m.current.Store(&sanitizer)
+	return m
+}
+
+// Sanitizer returns a snapshot of the current dictionary, it's not affected by later changes.
+func (m *MutableSanitizer) Sanitizer() *ProfanitySanitizer {
+	return m.current.Load().(*ProfanitySanitizer)
+}
+
+// List takes in a string (word or sentence) and returns list of DetectedConcern.
+func (m *MutableSanitizer) List(message string) []DetectedConcern {
+	return m.Sanitizer().List(message)
+}
+
+// AppendList appends detected concerns to dst and returns the extended slice.
+func (m *MutableSanitizer) AppendList(dst []DetectedConcern, message string) []DetectedConcern {
+	return m.Sanitizer().AppendList(dst, message)
+}
+
+// Redact takes in a string (word or sentence) and tries to censor all profanities found.
+func (m *MutableSanitizer) Redact(str string) string {
+	return m.Sanitizer().Redact(str)
+}
+
+// IsProfane checks whether there are any profanities in a given string (word or sentence).
+func (m *MutableSanitizer) IsProfane(str string) bool {
+	return m.Sanitizer().IsProfane(str)
+}
+
+// AddWord adds a profanity matched by word, using the leet speak and obfuscation settings.
+func (m *MutableSanitizer) AddWord(word string, level int32) error {
+	return m.addProfanity(WordMatcher{Word: word, Level: level})
+}
+
+// AddRegex adds a profanity matched by regex. Word is the optional base word reported in DetectedConcern.
+func (m *MutableSanitizer) AddRegex(word string, regex string, level int32) error {
+	return m.addProfanity(WordMatcher{Word: word, Regex: regex, Level: level})
+}
+
+// AddFalseNegative adds a word or regex that is always treated as profane, regardless of false positives.
+func (m *MutableSanitizer) AddFalseNegative(matcher WordMatcher) error {
+	return m.update(func(s *ProfanitySanitizer) error {
+		compiled, err := s.compile(matcher)
+		if err != nil {
+			return err
+		}
+		s.config.FalseNegatives = appendShared(s.config.FalseNegatives, compiled)
+		return nil
+	})
+}
+
+// AddFalsePositive adds a regex for words that contain profanities but are not profane themselves.
+func (m *MutableSanitizer) AddFalsePositive(regex string) error {
+	return m.update(func(s *ProfanitySanitizer) error {
+		compiled, err := regexp.Compile(regex)
+		if err != nil {
+			return err
+		}
+		s.config.FalsePositives = appendShared(s.config.FalsePositives, regex)
+		s.falsePositives = appendShared(s.falsePositives, compiled)
+		return nil
+	})
+}
+
+// RemoveWord removes all profanities and false negatives whose word or regex equals word.
+// It returns false if no entry was removed.
+func (m *MutableSanitizer) RemoveWord(word string) bool {
+	removed := false
+	_ = m.update(func(s *ProfanitySanitizer) error {
+		profanities := removeMatchers(s.config.Profanities, word)
+		falseNegatives := removeMatchers(s.config.FalseNegatives, word)
+		removed = len(profanities) != len(s.config.Profanities) || len(falseNegatives) != len(s.config.FalseNegatives)
+		s.config.Profanities = profanities
+		s.config.FalseNegatives = falseNegatives
+		return nil
+	})
+	return removed
+}
+
+// Config returns the current configuration, including all runtime changes.
+func (m *MutableSanitizer) Config() Config {
+	c := m.Sanitizer().config
+	c.Profanities = copyMatchers(c.Profanities)
+	c.FalsePositives = append([]string(nil), c.FalsePositives...)
+	c.FalseNegatives = copyMatchers(c.FalseNegatives)
+	c.Emoji = copyMatchers(c.Emoji)
+	c.AllowRules = append([]AllowRule(nil), c.AllowRules...)
+	c.Synonyms = copySynonyms(c.Synonyms)
+	return c
+}
+
+func copySynonyms(synonyms map[string][]string) map[string][]string {
+	if synonyms == nil {
+		return nil
+	}
+	copied := make(map[string][]string, len(synonyms))
+	for word, alternatives := range synonyms {
+		copied[word] = append([]string(nil), alternatives...)
+	}
+	return copied
+}
+
+// WriteConfig writes the current configuration in the config.json format,
+// so runtime changes can be persisted and loaded with DefaultConfig.
+func (m *MutableSanitizer) WriteConfig(w io.Writer) error {
+	c := m.Config()
+	return WriteConfig(w, &c)
+}
+
+func (m *MutableSanitizer) addProfanity(matcher WordMatcher) error {
+	return m.update(func(s *ProfanitySanitizer) error {
+		compiled, err := s.compile(matcher)
+		if err != nil {
+			return err
+		}
+		s.config.Profanities = appendShared(s.config.Profanities, compiled)
+		return nil
+	})
+}
+
+// update applies change to a copy of the current sanitizer and publishes it if change succeeds.
+func (m *MutableSanitizer) update(change func(s *ProfanitySanitizer) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	next := *m.Sanitizer()
+	if err := change(&next); err != nil {
+		return err
+	}
+	m.current.Store(&next)
+	return nil
+}
+
+func (gc *ProfanitySanitizer) compile(matcher WordMatcher) (WordMatcher, error) {
+	compiled, err := gc.config.compileMatcher(matcher)
+	if err != nil {
+		return matcher, err
+	}
+	matcher.Matcher = compiled
+	matcher.maxLength = maxMatchLength(compiled)
+	return matcher, nil
+}
+
+func removeMatchers(matchers []WordMatcher, word string) []WordMatcher {
+	kept := make([]WordMatcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		if matcher.Word != word && matcher.Regex != word {
+			kept = append(kept, matcher)
+		}
+	}
+	return kept
+}
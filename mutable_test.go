@@ -0,0 +1,116 @@
+package goclean
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestMutableSanitizer(t *testing.T) {
+	m := NewMutableSanitizer(DefaultConfig())
+	snapshot := m.Sanitizer()
+
+	if err := m.AddWord("heck", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.AddRegex("darn", "d[a]+rn", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.AddRegex("", "d[a+rn", 2); err == nil {
+		t.Errorf("expected error for invalid regex")
+	}
+	if err := m.AddFalsePositive("fuchsia"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.AddFalseNegative(WordMatcher{Word: "bassface"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !m.RemoveWord("shit") {
+		t.Errorf("expected shit to be removed")
+	}
+	if m.RemoveWord("unknown") {
+		t.Errorf("unknown word should not be removed")
+	}
+
+	tests := []struct {
+		text         string
+		want         string
+		wantSnapshot string
+	}{
+		{"h.e.c.k", "*******", "h.e.c.k"},
+		{"daaarn", "******", "daaarn"},
+		{"bassface", "********", "bassface"},
+		{"shit", "shit", "****"},
+		{"fuck", "****", "****"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			if got := m.Redact(test.text); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+			if got := snapshot.Redact(test.text); got != test.wantSnapshot {
+				t.Errorf("snapshot: got %s, want %s", got, test.wantSnapshot)
+			}
+		})
+	}
+}
+
+func TestMutableSanitizer_Config(t *testing.T) {
+	c := DefaultConfig()
+	c.Synonyms = map[string][]string{"ass": {"donkey"}}
+	m := NewMutableSanitizer(c)
+	copied := m.Config()
+	copied.Synonyms["ass"][0] = "mule"
+	copied.Synonyms["dick"] = []string{"duck"}
+	if got := m.Config().Synonyms; !reflect.DeepEqual(got, map[string][]string{"ass": {"donkey"}}) {
+		t.Errorf("changing the returned config should not change the sanitizer, got %v", got)
+	}
+}
+
+func TestMutableSanitizer_WriteConfig(t *testing.T) {
+	m := NewMutableSanitizer(DefaultConfig())
+	_ = m.AddRegex("darn", "d[a]+rn", 2)
+	_ = m.AddFalsePositive("fuchsia")
+	var buf bytes.Buffer
+	if err := m.WriteConfig(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("Matcher")) {
+		t.Errorf("compiled matchers should not be exported")
+	}
+	c := &Config{}
+	if err := json.Unmarshal(buf.Bytes(), c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reloaded := NewProfanitySanitizer(c)
+	if !reloaded.IsProfane("daaarn") || reloaded.IsProfane("fuchsia") || !reloaded.IsProfane("fuck") {
+		t.Errorf("reloaded config should contain the runtime changes")
+	}
+}
+
+func TestMutableSanitizer_Concurrent(t *testing.T) {
+	m := NewMutableSanitizer(DefaultConfig())
+	words := []string{"heck", "darn", "frick", "frack", "gosh"}
+	var wg sync.WaitGroup
+	for _, word := range words {
+		wg.Add(2)
+		go func(word string) {
+			defer wg.Done()
+			_ = m.AddWord(word, 1)
+		}(word)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.IsProfane("what the heck")
+			}
+		}()
+	}
+	wg.Wait()
+	for _, word := range words {
+		if !m.IsProfane(word) {
+			t.Errorf("%s should be profane", word)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package goclean
+
+import (
+	"context"
+	"sort"
+	"unicode/utf8"
+)
+
+// ListOptions configures ListWithOptions.
+type ListOptions struct {
+	// RuneOffsets sets RuneStart and RuneEnd of the concerns.
+	RuneOffsets bool
+	// UTF16Offsets sets UTF16Start and UTF16End of the concerns, for clients that index strings by UTF-16 code units,
+	// like JavaScript, Java or Swift's utf16 view.
+	UTF16Offsets bool
+	// GraphemeOffsets sets GraphemeStart and GraphemeEnd of the concerns, for clients that index strings by
+	// user-perceived characters, like Swift.
+	GraphemeOffsets bool
+	// Contexts are the context tags of the message, like the channel it was sent to, for the allow rules.
+	Contexts []string
+	// SourceOffsets sets StartIndex and EndIndex, and the offsets enabled in the options, to offsets in the message
+	// as it was passed instead of the sanitized message, e.g. to compare them with offsets computed by the client.
+	// MatchedText is still the sanitized text.
+	SourceOffsets bool
+}
+
+// ListWithOptions works like List, applies the allow rules for the contexts of the options and sets the offsets
+// of the concerns enabled in the options. All offsets are computed in one pass over the message.
+//
+// Like StartIndex and EndIndex, the offsets refer to the message after sanitization, in which combining marks are
+// removed, so "é" written as "e" and U+0301 is one rune and one UTF-16 code unit, unless SourceOffsets is set.
+func (gc *ProfanitySanitizer) ListWithOptions(message string, opts ListOptions) []DetectedConcern {
+	if opts.SourceOffsets {
+		return gc.listSource(message, opts)
+	}
+	s := getScratch()
+	defer putScratch(s)
+	sanitized := s.sanitize(message, gc.config.InvalidUTF8)
+	concerns, _ := gc.detectIn(context.Background(), make([]DetectedConcern, 0), sanitized, &s.matched, detectOptions{contexts: opts.Contexts})
+	if opts.RuneOffsets || opts.UTF16Offsets || opts.GraphemeOffsets {
+		setOffsets(sanitized, concerns, opts)
+	}
+	return concerns
+}
+
+// listSource lists the concerns with offsets in the message, by mapping every rune of the message
+// to its sanitized text.
+func (gc *ProfanitySanitizer) listSource(message string, opts ListOptions) []DetectedConcern {
+	m := newMappedText(&gc.config)
+	defer m.release()
+	m.opts.contexts = opts.Contexts
+	m.appendSource(message, 0)
+	concerns := m.list(gc)
+	if opts.RuneOffsets || opts.UTF16Offsets || opts.GraphemeOffsets {
+		setOffsets(message, concerns, opts)
+	}
+	return concerns
+}
+
+// textOffset is a byte offset of a concern boundary and its offsets in the other units.
+type textOffset struct {
+	index, runes, utf16 int
+	// graphemeStart is the index of the grapheme cluster that contains the byte, graphemeEnd is the number of
+	// grapheme clusters that start before it, they only differ inside a cluster.
+	graphemeStart, graphemeEnd int
+}
+
+func setOffsets(message string, concerns []DetectedConcern, opts ListOptions) {
+	offsets := make([]textOffset, 0, 2*len(concerns))
+	for _, concern := range concerns {
+		offsets = append(offsets, textOffset{index: int(concern.StartIndex)}, textOffset{index: int(concern.EndIndex)})
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].index < offsets[j].index })
+
+	runes, utf16, graphemes := 0, 0, 0
+	clusterEnd := 0
+	next := 0
+	for i := 0; next < len(offsets); {
+		for next < len(offsets) && offsets[next].index <= i {
+			offset := &offsets[next]
+			offset.runes, offset.utf16 = runes, utf16
+			offset.graphemeStart, offset.graphemeEnd = graphemes, graphemes
+			if i < clusterEnd {
+				offset.graphemeStart--
+			}
+			next++
+		}
+		if i >= len(message) {
+			break
+		}
+		if opts.GraphemeOffsets && i == clusterEnd {
+			clusterEnd += nextGrapheme(message[i:])
+			graphemes++
+		}
+		r, size := utf8.DecodeRuneInString(message[i:])
+		runes++
+		utf16++
+		if r >= 0x10000 {
+			utf16++
+		}
+		i += size
+	}
+
+	for i := range concerns {
+		start := findOffset(offsets, int(concerns[i].StartIndex))
+		end := findOffset(offsets, int(concerns[i].EndIndex))
+		if opts.RuneOffsets {
+			concerns[i].RuneStart, concerns[i].RuneEnd = int32(start.runes), int32(end.runes)
+		}
+		if opts.UTF16Offsets {
+			concerns[i].UTF16Start, concerns[i].UTF16End = int32(start.utf16), int32(end.utf16)
+		}
+		if opts.GraphemeOffsets {
+			concerns[i].GraphemeStart, concerns[i].GraphemeEnd = int32(start.graphemeStart), int32(end.graphemeEnd)
+		}
+	}
+}
+
+func findOffset(offsets []textOffset, index int) textOffset {
+	return offsets[sort.Search(len(offsets), func(i int) bool { return offsets[i].index >= index })]
+}
+
+// ListWithOptions works like List and sets the offsets of the concerns enabled in the options.
+//
+// Uses the default ProfanitySanitizer
+func ListWithOptions(message string, opts ListOptions) []DetectedConcern {
+	return gc.ListWithOptions(message, opts)
+}
@@ -0,0 +1,55 @@
+package goclean
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoClean_ListWithOptions(t *testing.T) {
+	all := ListOptions{RuneOffsets: true, UTF16Offsets: true, GraphemeOffsets: true}
+	tests := []struct {
+		name string
+		text string
+		opts ListOptions
+		want []DetectedConcern
+	}{
+		{"no offsets", "😀 ass", ListOptions{}, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 5, EndIndex: 8, Level: 2},
+		}},
+		{"ascii", "what the fuck", all, []DetectedConcern{
+			{MatchedText: "fuck", StartIndex: 9, EndIndex: 13, RuneStart: 9, RuneEnd: 13, UTF16Start: 9, UTF16End: 13, GraphemeStart: 9, GraphemeEnd: 13},
+		}},
+		{"surrogate pairs", "😀 ass", all, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 5, EndIndex: 8, Level: 2, RuneStart: 2, RuneEnd: 5, UTF16Start: 3, UTF16End: 6, GraphemeStart: 2, GraphemeEnd: 5},
+		}},
+		{"CJK", "世界 ASS", all, []DetectedConcern{
+			{Word: "ass", MatchedText: "ASS", StartIndex: 7, EndIndex: 10, Level: 2, RuneStart: 3, RuneEnd: 6, UTF16Start: 3, UTF16End: 6, GraphemeStart: 3, GraphemeEnd: 6},
+		}},
+		{"ZWJ sequences and modifiers", "👨‍👩‍👦 ass 🖕🏿", all, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 19, EndIndex: 22, Level: 2, RuneStart: 6, RuneEnd: 9, UTF16Start: 9, UTF16End: 12, GraphemeStart: 2, GraphemeEnd: 5},
+			{Word: "🖕", MatchedText: "🖕🏿", StartIndex: 23, EndIndex: 31, Level: 2, Category: "gesture", RuneStart: 10, RuneEnd: 12, UTF16Start: 13, UTF16End: 17, GraphemeStart: 6, GraphemeEnd: 7},
+		}},
+		{"combining sequences", "क्षि ass", all, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 10, EndIndex: 13, Level: 2, RuneStart: 4, RuneEnd: 7, UTF16Start: 4, UTF16End: 7, GraphemeStart: 3, GraphemeEnd: 6},
+		}},
+		{"end inside a grapheme cluster", "ass⃣", all, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 0, EndIndex: 3, Level: 2, RuneStart: 0, RuneEnd: 3, UTF16Start: 0, UTF16End: 3, GraphemeStart: 0, GraphemeEnd: 3},
+		}},
+		{"only UTF-16", "😀 ass", ListOptions{UTF16Offsets: true}, []DetectedConcern{
+			{Word: "ass", MatchedText: "ass", StartIndex: 5, EndIndex: 8, Level: 2, UTF16Start: 3, UTF16End: 6},
+		}},
+		{"source offsets", "ééééé shit", ListOptions{SourceOffsets: true}, []DetectedConcern{
+			{Word: "shit", MatchedText: "shit", StartIndex: 11, EndIndex: 15},
+		}},
+		{"source offsets with combining marks", "e\u0301 fu\u0308ck", ListOptions{SourceOffsets: true, RuneOffsets: true}, []DetectedConcern{
+			{MatchedText: "fuck", StartIndex: 4, EndIndex: 10, RuneStart: 3, RuneEnd: 8},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ListWithOptions(test.text, test.opts); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package goclean
+
+import (
+	"sort"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// scratch holds the buffers needed to check a single message, so they can be
+// reused between calls instead of being allocated for every message.
+type scratch struct {
+	normalize transform.Transformer
+	src       []byte
+	dst       []byte
+	matched   spanSet
+}
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		return &scratch{
+			normalize: transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC),
+		}
+	},
+}
+
+func getScratch() *scratch {
+	return scratchPool.Get().(*scratch)
+}
+
+func putScratch(s *scratch) {
+	s.matched.reset()
+	scratchPool.Put(s)
+}
+
+// sanitize removes diacritics from the message and handles invalid UTF-8 according to the policy,
+// the result is always valid UTF-8. ASCII messages are returned as they are, as normalization would not change them.
+func (s *scratch) sanitize(message string, policy InvalidUTF8Policy) string {
+	if isASCII(message) {
+		return message
+	}
+	s.src = appendValidUTF8(s.src[:0], message, policy)
+	// normalization can change the length of the message, the destination grows until everything is transformed
+	if cap(s.dst) < len(s.src) {
+		s.dst = make([]byte, len(s.src))
+	}
+	s.dst = s.dst[:cap(s.dst)]
+	s.normalize.Reset()
+	n, src := 0, s.src
+	for {
+		nDst, nSrc, err := s.normalize.Transform(s.dst[n:], src, true)
+		n, src = n+nDst, src[nSrc:]
+		if err == nil {
+			break
+		}
+		if err != transform.ErrShortDst {
+			return string(s.src)
+		}
+		s.dst = append(s.dst, make([]byte, len(s.dst))...)
+	}
+	return string(s.dst[:n])
+}
+
+// appendValidUTF8 appends the message to dst, replacing or dropping invalid bytes.
+func appendValidUTF8(dst []byte, message string, policy InvalidUTF8Policy) []byte {
+	if utf8.ValidString(message) {
+		return append(dst, message...)
+	}
+	for i := 0; i < len(message); {
+		r, size := utf8.DecodeRuneInString(message[i:])
+		switch {
+		case r != utf8.RuneError || size > 1:
+			dst = append(dst, message[i:i+size]...)
+		case policy != InvalidUTF8Drop:
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+		}
+		i += size
+	}
+	return dst
+}
+
+// invalidUTF8Offset returns the byte offset of the first invalid UTF-8 sequence in the message, or -1.
+func invalidUTF8Offset(message string) int {
+	for i, r := range message {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(message[i:]); size == 1 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// spanSet is a set of byte indexes stored as sorted, non-overlapping [start, end) intervals.
+type spanSet struct {
+	spans []span
+}
+
+type span struct {
+	start, end int
+}
+
+func (s *spanSet) add(start, end int) {
+	if start >= end {
+		return
+	}
+	// first span that ends at or after start, it is either merged with the new span or follows it
+	i := sort.Search(len(s.spans), func(i int) bool { return s.spans[i].end >= start })
+	j := i
+	for j < len(s.spans) && s.spans[j].start <= end {
+		if s.spans[j].start < start {
+			start = s.spans[j].start
+		}
+		if s.spans[j].end > end {
+			end = s.spans[j].end
+		}
+		j++
+	}
+	if i == j {
+		s.spans = append(s.spans, span{})
+		copy(s.spans[i+1:], s.spans[i:])
+		s.spans[i] = span{start, end}
+		return
+	}
+	s.spans[i] = span{start, end}
+	s.spans = append(s.spans[:i+1], s.spans[j:]...)
+}
+
+func (s *spanSet) contains(index int) bool {
+	i := sort.Search(len(s.spans), func(i int) bool { return s.spans[i].end > index })
+	return i < len(s.spans) && s.spans[i].start <= index
+}
+
+// isAlreadyMatched reports whether the [start, end) interval overlaps any span of the set.
+func (s *spanSet) isAlreadyMatched(start, end int) bool {
+	i := sort.Search(len(s.spans), func(i int) bool { return s.spans[i].end > start })
+	return i < len(s.spans) && s.spans[i].start < end
+}
+
+func (s *spanSet) reset() {
+	s.spans = s.spans[:0]
+}
@@ -0,0 +1,99 @@
+package goclean
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpanSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans [][2]int
+		want  []span
+	}{
+		{"empty span is ignored", [][2]int{{3, 3}}, []span{}},
+		{"disjoint spans are sorted", [][2]int{{10, 12}, {0, 2}, {5, 6}}, []span{{0, 2}, {5, 6}, {10, 12}}},
+		{"overlapping spans are merged", [][2]int{{0, 4}, {2, 6}}, []span{{0, 6}}},
+		{"adjacent spans are merged", [][2]int{{0, 3}, {3, 5}}, []span{{0, 5}}},
+		{"span covering several spans", [][2]int{{1, 2}, {4, 5}, {7, 8}, {0, 6}}, []span{{0, 6}, {7, 8}}},
+		{"span inside another span", [][2]int{{0, 10}, {2, 3}}, []span{{0, 10}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := spanSet{spans: []span{}}
+			indexes := make(map[int]bool)
+			for _, s := range test.spans {
+				set.add(s[0], s[1])
+				for i := s[0]; i < s[1]; i++ {
+					indexes[i] = true
+				}
+			}
+			if len(set.spans) != len(test.want) {
+				t.Fatalf("got %v, want %v", set.spans, test.want)
+			}
+			for i := range test.want {
+				if set.spans[i] != test.want[i] {
+					t.Fatalf("got %v, want %v", set.spans, test.want)
+				}
+			}
+			for i := -1; i < 15; i++ {
+				if set.contains(i) != indexes[i] {
+					t.Errorf("contains(%d) = %t, want %t", i, set.contains(i), indexes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSpanSet_IsAlreadyMatched(t *testing.T) {
+	set := spanSet{}
+	set.add(3, 6)
+	set.add(10, 12)
+	tests := []struct {
+		name       string
+		start, end int
+		want       bool
+	}{
+		{"before", 0, 3, false},
+		{"after", 6, 10, false},
+		{"overlapping start", 5, 8, true},
+		{"overlapping end", 1, 4, true},
+		{"inside", 4, 5, true},
+		{"covering", 2, 7, true},
+		{"covering several spans", 0, 20, true},
+		{"past the last span", 12, 15, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := set.isAlreadyMatched(test.start, test.end); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeString(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		policy InvalidUTF8Policy
+		want   string
+	}{
+		{"ascii", "hello world", "", "hello world"},
+		{"diacritics", "fûçk", "", "fuck"},
+		{"multi-byte characters", "世界 ASS", "", "世界 ASS"},
+		{"longer after normalization", strings.Repeat("\uFA6C", 10) + "é", "", strings.Repeat("\U000242EE", 10) + "e"},
+		{"longer after replacing invalid bytes", strings.Repeat("\xff", 10) + "é", "", strings.Repeat("\uFFFD", 10) + "e"},
+		{"replace invalid bytes", "a\xffs\xe2\x80s", InvalidUTF8Replace, "a\uFFFDs\uFFFD\uFFFDs"},
+		{"drop invalid bytes", "a\xffs\xe2\x80s", InvalidUTF8Drop, "ass"},
+		{"reject replaces invalid bytes", "\xffé", InvalidUTF8Reject, "\uFFFDe"},
+		{"many combining marks", "a" + strings.Repeat("\u0301", 100) + "ss", "", "ass"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sanitizeString(test.text, test.policy); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
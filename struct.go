@@ -0,0 +1,300 @@
+package goclean
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a field of a struct that contains profanities.
+type FieldError struct {
+	// Path is the path of the field, e.g. `Comments[2].Text` or `Labels["en"]`. It's empty if the validated
+	// value is a string, see Rule.
+	Path     string
+	Concerns []DetectedConcern
+}
+
+func (e *FieldError) Error() string {
+	matched := make([]string, len(e.Concerns))
+	for i, concern := range e.Concerns {
+		matched[i] = strconv.Quote(concern.MatchedText)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("goclean: value contains profanity %s", strings.Join(matched, ", "))
+	}
+	return fmt.Sprintf("goclean: field %s contains profanity %s", e.Path, strings.Join(matched, ", "))
+}
+
+// ValidationError is returned by Validate when some fields contain profanities.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 1 {
+		return e.Fields[0].Error()
+	}
+	paths := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		paths[i] = field.Path
+	}
+	return fmt.Sprintf("goclean: %d fields contain profanities: %s", len(e.Fields), strings.Join(paths, ", "))
+}
+
+// Validate checks the string fields of a struct tagged with `goclean:"reject"` and returns *ValidationError
+// listing every field that contains profanities.
+//
+// The tag options are:
+//   - reject: the field is checked by Validate
+//   - redact: the field is redacted by RedactStruct
+//   - level=N: only concerns with at least level N are taken into account, concerns of dictionary entries
+//     without a level have level 1
+//
+// Nested structs, pointers, slices, arrays, maps and interfaces are traversed, a pointer, map or slice that contains
+// itself only once, so cyclic values terminate. The tag of a slice, array or map field applies to the strings it
+// contains, nested structs use the tags of their own fields.
+// Unexported fields and fields tagged with `goclean:"-"` are skipped.
+func (gc *ProfanitySanitizer) Validate(v interface{}) error {
+	w := &structWalker{sanitizer: gc, visited: make(map[visit]bool)}
+	if err := w.walk(reflect.ValueOf(v), "", nil); err != nil {
+		return err
+	}
+	if len(w.fields) > 0 {
+		return &ValidationError{Fields: w.fields}
+	}
+	return nil
+}
+
+// RedactStruct redacts the string fields tagged with `goclean:"redact"` in the struct v points to.
+// Fields without profanities are left as they are. See Validate for the tag options.
+func (gc *ProfanitySanitizer) RedactStruct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("goclean: RedactStruct requires a non-nil pointer")
+	}
+	w := &structWalker{sanitizer: gc, redact: true, visited: make(map[visit]bool)}
+	return w.walk(value, "", nil)
+}
+
+// fieldRule contains the options of a goclean struct tag.
+type fieldRule struct {
+	reject bool
+	redact bool
+	level  int32
+}
+
+func parseFieldRule(tag string) (*fieldRule, error) {
+	rule := &fieldRule{}
+	for _, option := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch name {
+		case "reject":
+			rule.reject = true
+		case "redact":
+			rule.redact = true
+		case "level":
+			level, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid level %q", value)
+			}
+			rule.level = int32(level)
+		default:
+			return nil, fmt.Errorf("unknown option %q", option)
+		}
+	}
+	return rule, nil
+}
+
+type structWalker struct {
+	sanitizer *ProfanitySanitizer
+	redact    bool
+	fields    []FieldError
+	// visited contains the pointers, maps and slices on the path to the current value, so cyclic structures
+	// terminate while values shared by several fields are checked with the rule of each field.
+	visited map[visit]bool
+}
+
+type visit struct {
+	pointer uintptr
+	typ     reflect.Type
+	len     int
+}
+
+func visitOf(v reflect.Value) visit {
+	key := visit{pointer: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	return key
+}
+
+// enter adds the pointer, map or slice v to the current path. It returns false if v is already on the path.
+func (w *structWalker) enter(v reflect.Value) bool {
+	key := visitOf(v)
+	if w.visited[key] {
+		return false
+	}
+	w.visited[key] = true
+	return true
+}
+
+// leave removes v from the current path.
+func (w *structWalker) leave(v reflect.Value) {
+	delete(w.visited, visitOf(v))
+}
+
+// walk traverses v, rule is the rule of the closest tagged field or nil.
+func (w *structWalker) walk(v reflect.Value, path string, rule *fieldRule) error {
+	switch v.Kind() {
+	case reflect.String:
+		if rule != nil {
+			w.check(v, path, rule)
+		}
+	case reflect.Ptr:
+		if v.IsNil() || !w.enter(v) {
+			return nil
+		}
+		defer w.leave(v)
+		return w.walk(v.Elem(), path, rule)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return w.walkCopy(v.Elem(), path, rule, func(elem reflect.Value) {
+			if v.CanSet() {
+				v.Set(elem)
+			}
+		})
+	case reflect.Struct:
+		return w.walkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() || !w.enter(v) {
+				return nil
+			}
+			defer w.leave(v)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), rule); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() || !w.enter(v) {
+			return nil
+		}
+		defer w.leave(v)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			keyPath := fmt.Sprintf("%s[%v]", path, key)
+			if key.Kind() == reflect.String {
+				keyPath = fmt.Sprintf("%s[%q]", path, key)
+			}
+			err := w.walkCopy(v.MapIndex(key), keyPath, rule, func(elem reflect.Value) {
+				v.SetMapIndex(key, elem)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkCopy traverses a value that can not be modified in place, like a map element, and stores it
+// with set if it was redacted.
+func (w *structWalker) walkCopy(v reflect.Value, path string, rule *fieldRule, set func(reflect.Value)) error {
+	if !w.redact || v.Kind() == reflect.Ptr {
+		return w.walk(v, path, rule)
+	}
+	elem := reflect.New(v.Type()).Elem()
+	elem.Set(v)
+	if err := w.walk(elem, path, rule); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(elem.Interface(), v.Interface()) {
+		set(elem)
+	}
+	return nil
+}
+
+func (w *structWalker) walkStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		var rule *fieldRule
+		if tag, ok := field.Tag.Lookup("goclean"); ok {
+			if tag == "-" {
+				continue
+			}
+			var err error
+			if rule, err = parseFieldRule(tag); err != nil {
+				return fmt.Errorf("goclean: invalid tag of field %s: %w", fieldPath, err)
+			}
+		}
+		if err := w.walk(v.Field(i), fieldPath, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *structWalker) check(v reflect.Value, path string, rule *fieldRule) {
+	if w.redact {
+		if !rule.redact || !v.CanSet() {
+			return
+		}
+	} else if !rule.reject {
+		return
+	}
+	sanitized := sanitizeString(v.String(), w.sanitizer.config.InvalidUTF8)
+	concerns := w.sanitizer.List(sanitized)
+	filtered := concerns[:0]
+	for _, concern := range concerns {
+		if effectiveLevel(concern) >= rule.level {
+			filtered = append(filtered, concern)
+		}
+	}
+	if len(filtered) == 0 {
+		return
+	}
+	if w.redact {
+		v.SetString(w.sanitizer.redactConcerns(sanitized, filtered))
+		return
+	}
+	w.fields = append(w.fields, FieldError{Path: path, Concerns: filtered})
+}
+
+// effectiveLevel returns the level of the concern for the level thresholds. Entries without a level are reported
+// with level 0, they have the lowest level 1, so a threshold of 1 does not let them through.
+func effectiveLevel(concern DetectedConcern) int32 {
+	if concern.Level == 0 {
+		return 1
+	}
+	return concern.Level
+}
+
+// Validate checks the string fields of a struct tagged with `goclean:"reject"`.
+//
+// Uses the default ProfanitySanitizer
+func Validate(v interface{}) error {
+	return gc.Validate(v)
+}
+
+// RedactStruct redacts the string fields tagged with `goclean:"redact"` in the struct v points to.
+//
+// Uses the default ProfanitySanitizer
+func RedactStruct(v interface{}) error {
+	return gc.RedactStruct(v)
+}
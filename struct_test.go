@@ -0,0 +1,202 @@
+package goclean
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testComment struct {
+	Text   string `goclean:"reject,redact"`
+	Author string
+}
+
+type testProfile struct {
+	DisplayName string            `goclean:"reject,redact"`
+	Bio         *string           `goclean:"redact"`
+	Nickname    string            `goclean:"reject,level=2"`
+	Comments    []testComment     `goclean:"reject"`
+	Tags        []string          `goclean:"reject,redact"`
+	Labels      map[string]string `goclean:"reject,redact"`
+	Extra       interface{}       `goclean:"reject,redact"`
+	Internal    string            `goclean:"-"`
+	Parent      *testProfile
+	secret      string `goclean:"reject"`
+}
+
+func TestGoClean_Validate(t *testing.T) {
+	bio := "shit happens"
+	profile := testProfile{
+		DisplayName: "fuck",
+		Bio:         &bio,
+		Nickname:    "crap",
+		Comments:    []testComment{{Text: "hello", Author: "ass"}, {Text: "a.s.s"}},
+		Tags:        []string{"music", "shit"},
+		Labels:      map[string]string{"en": "damn", "de": "hallo"},
+		Extra:       []string{"fuck"},
+		Internal:    "shit",
+		secret:      "shit",
+	}
+	profile.Parent = &profile
+
+	err := Validate(&profile)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got %v, want ValidationError", err)
+	}
+	var paths []string
+	for _, field := range validationErr.Fields {
+		paths = append(paths, field.Path)
+	}
+	want := []string{"DisplayName", "Comments[1].Text", "Tags[1]", `Labels["en"]`, "Extra[0]"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+	if concerns := validationErr.Fields[0].Concerns; len(concerns) != 1 || concerns[0].MatchedText != "fuck" {
+		t.Errorf("got concerns %v", concerns)
+	}
+	if got := validationErr.Fields[0].Error(); got != `goclean: field DisplayName contains profanity "fuck"` {
+		t.Errorf("got %s", got)
+	}
+
+	if err := Validate(&testProfile{DisplayName: "hello", Nickname: "crap"}); err != nil {
+		t.Errorf("got %v, want no error", err)
+	}
+}
+
+func TestGoClean_RedactStruct(t *testing.T) {
+	bio := "shit happens"
+	profile := &testProfile{
+		DisplayName: "Fûçk",
+		Bio:         &bio,
+		Nickname:    "crap",
+		Comments:    []testComment{{Text: "a.s.s", Author: "ass"}},
+		Tags:        []string{"Café", "shit"},
+		Labels:      map[string]string{"en": "damn", "fr": "éclair"},
+		Extra:       "fuck",
+		Internal:    "shit",
+	}
+	if err := RedactStruct(profile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &testProfile{
+		DisplayName: "****",
+		Bio:         profile.Bio,
+		Nickname:    "crap",
+		Comments:    []testComment{{Text: "*****", Author: "ass"}},
+		Tags:        []string{"Café", "****"},
+		Labels:      map[string]string{"en": "****", "fr": "éclair"},
+		Extra:       "****",
+		Internal:    "shit",
+	}
+	if !reflect.DeepEqual(profile, want) {
+		t.Errorf("got %+v, want %+v", profile, want)
+	}
+	if bio != "**** happens" {
+		t.Errorf("got bio %q", bio)
+	}
+
+	if err := RedactStruct(testProfile{}); err == nil {
+		t.Errorf("expected error for a struct that is not a pointer")
+	}
+}
+
+func TestGoClean_StructCycles(t *testing.T) {
+	type node struct {
+		Text     string      `goclean:"reject,redact"`
+		Children interface{} `goclean:"reject,redact"`
+	}
+	labels := map[string]interface{}{"text": "shit"}
+	labels["self"] = labels
+	list := []interface{}{"fuck", nil}
+	list[1] = list
+	v := &node{Text: "ass", Children: []interface{}{labels, list}}
+
+	err := Validate(v)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Fields) != 3 {
+		t.Fatalf("got %v, want 3 fields", err)
+	}
+	if err := RedactStruct(v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Text != "***" || labels["text"] != "****" || list[0] != "****" {
+		t.Errorf("got %q %q %q", v.Text, labels["text"], list[0])
+	}
+}
+
+func TestGoClean_StructLevels(t *testing.T) {
+	type levels struct {
+		Any    string `goclean:"reject"`
+		Lowest string `goclean:"reject,level=1"`
+		Higher string `goclean:"reject,level=2"`
+	}
+	// "fuck" has no level in the default config
+	err := Validate(levels{Any: "fuck", Lowest: "fuck", Higher: "fuck"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got %v, want ValidationError", err)
+	}
+	var paths []string
+	for _, field := range validationErr.Fields {
+		paths = append(paths, field.Path)
+	}
+	if want := []string{"Any", "Lowest"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
+
+func TestGoClean_StructSharedValues(t *testing.T) {
+	type shared struct {
+		Untagged *string
+		Lenient  *string `goclean:"reject,level=3"`
+		Strict   *string `goclean:"reject,redact"`
+		Words    []string
+		Tags     []string `goclean:"reject,redact"`
+	}
+	text := "shit"
+	words := []string{"hello", "fuck"}
+	v := &shared{Untagged: &text, Lenient: &text, Strict: &text, Words: words, Tags: words}
+
+	err := Validate(v)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got %v, want ValidationError", err)
+	}
+	var paths []string
+	for _, field := range validationErr.Fields {
+		paths = append(paths, field.Path)
+	}
+	if want := []string{"Strict", "Tags[1]"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+	if err := RedactStruct(v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "****" || words[1] != "****" {
+		t.Errorf("got %q %q", text, words[1])
+	}
+}
+
+func TestGoClean_ValidateInvalidTag(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"unknown option", struct {
+			Name string `goclean:"rejekt"`
+		}{}},
+		{"invalid level", struct {
+			Name string `goclean:"reject,level=high"`
+		}{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.v)
+			var validationErr *ValidationError
+			if err == nil || errors.As(err, &validationErr) {
+				t.Errorf("got %v, want tag error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package goclean
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Layer contains the dictionary additions and option overrides a tenant applies on top of a base ProfanitySanitizer.
+//
+// Detection options (DetectLeetSpeak, DetectObfuscated, ObfuscationLength) are taken from the base,
+// as the base matchers are shared and compiled with them.
+type Layer struct {
+	Profanities    []WordMatcher `json:"profanities"`
+	FalsePositives []string      `json:"falsePositives"`
+	FalseNegatives []WordMatcher `json:"falseNegatives"`
+	Emoji          []WordMatcher `json:"emoji,omitempty"`
+	AllowRules     []AllowRule   `json:"allowRules,omitempty"`
+	// Synonyms are added to the synonyms of the base.
+	Synonyms map[string][]string `json:"synonyms,omitempty"`
+
+	// ReplacementCharacter overrides the replacement character of the base if set.
+	ReplacementCharacter string `json:"replacementCharacter,omitempty"`
+	// MaxInputLength overrides the maximum input length of the base if set.
+	MaxInputLength int `json:"maxInputLength,omitempty"`
+	// InvalidUTF8 overrides the invalid UTF-8 policy of the base if set.
+	InvalidUTF8 InvalidUTF8Policy `json:"invalidUTF8,omitempty"`
+}
+
+// Derive creates a new ProfanitySanitizer that extends this one with the given Layer.
+//
+// Only the matchers added by the layer are compiled, the compiled matchers of the base are shared
+// with the derived sanitizer. The base sanitizer is not modified. An error is returned if a regex
+// of the layer is invalid.
+func (gc *ProfanitySanitizer) Derive(layer Layer) (ProfanitySanitizer, error) {
+	c := gc.config
+	if layer.ReplacementCharacter != "" {
+		c.ReplacementCharacter = layer.ReplacementCharacter
+	}
+	if layer.MaxInputLength != 0 {
+		c.MaxInputLength = layer.MaxInputLength
+	}
+	if layer.InvalidUTF8 != "" {
+		c.InvalidUTF8 = layer.InvalidUTF8
+	}
+	profanities := copyMatchers(layer.Profanities)
+	if err := c.compileMatchers(profanities); err != nil {
+		return ProfanitySanitizer{}, err
+	}
+	falseNegatives := copyMatchers(layer.FalseNegatives)
+	if err := c.compileMatchers(falseNegatives); err != nil {
+		return ProfanitySanitizer{}, err
+	}
+	emoji := copyMatchers(layer.Emoji)
+	if err := compileEmojiMatchers(emoji); err != nil {
+		return ProfanitySanitizer{}, err
+	}
+	falsePositives, err := compileFalsePositives(layer.FalsePositives)
+	if err != nil {
+		return ProfanitySanitizer{}, err
+	}
+	c.Profanities = appendShared(gc.config.Profanities, profanities...)
+	c.FalseNegatives = appendShared(gc.config.FalseNegatives, falseNegatives...)
+	c.FalsePositives = appendShared(gc.config.FalsePositives, layer.FalsePositives...)
+	c.Emoji = appendShared(gc.config.Emoji, emoji...)
+	c.AllowRules = appendShared(gc.config.AllowRules, layer.AllowRules...)
+	if len(layer.Synonyms) > 0 {
+		c.Synonyms = make(map[string][]string, len(gc.config.Synonyms)+len(layer.Synonyms))
+		for word, synonyms := range gc.config.Synonyms {
+			c.Synonyms[word] = synonyms
+		}
+		for word, synonyms := range layer.Synonyms {
+			c.Synonyms[word] = appendShared(c.Synonyms[word], synonyms...)
+		}
+	}
+	return ProfanitySanitizer{
+		config:         c,
+		falsePositives: appendShared(gc.falsePositives, falsePositives...),
+	}, nil
+}
+
+// appendShared appends to a copy of base, so slices of the base sanitizer are never written to.
+func appendShared[T any](base []T, elems ...T) []T {
+	return append(base[:len(base):len(base)], elems...)
+}
+
+func copyMatchers(matchers []WordMatcher) []WordMatcher {
+	return append([]WordMatcher(nil), matchers...)
+}
+
+// LayerLoader returns the Layer of a tenant. It is used by Registry to derive sanitizers that are not cached.
+type LayerLoader func(tenantID string) (Layer, error)
+
+// Registry caches sanitizers derived from a shared base for each tenant.
+//
+// Once the registry holds more sanitizers than its capacity, the least recently used one is evicted
+// and derived again from its layer the next time it is requested. It is safe for concurrent use.
+type Registry struct {
+	base     ProfanitySanitizer
+	load     LayerLoader
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type registryEntry struct {
+	tenantID  string
+	sanitizer *ProfanitySanitizer
+}
+
+// NewRegistry creates a new Registry that derives tenant sanitizers from base using the layers returned by load.
+//
+// Capacity lower than 1 means the registry is unbounded.
+func NewRegistry(base ProfanitySanitizer, capacity int, load LayerLoader) *Registry {
+	return &Registry{
+		base:     base,
+		load:     load,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Base returns the sanitizer all tenant sanitizers are derived from.
+func (r *Registry) Base() *ProfanitySanitizer {
+	return &r.base
+}
+
+// Get returns the sanitizer of the given tenant, deriving it if it is not cached.
+// Errors of the loader and of Derive are returned and nothing is cached, so the layer is loaded again
+// on the next Get.
+func (r *Registry) Get(tenantID string) (*ProfanitySanitizer, error) {
+	if sanitizer, ok := r.cached(tenantID); ok {
+		return sanitizer, nil
+	}
+	layer, err := r.load(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	sanitizer, err := r.base.Derive(layer)
+	if err != nil {
+		return nil, err
+	}
+	return r.Set(tenantID, &sanitizer), nil
+}
+
+// Set stores the sanitizer of the given tenant, replacing the cached one.
+// It returns the sanitizer stored in the registry.
+func (r *Registry) Set(tenantID string, sanitizer *ProfanitySanitizer) *ProfanitySanitizer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if element, ok := r.entries[tenantID]; ok {
+		element.Value.(*registryEntry).sanitizer = sanitizer
+		r.lru.MoveToFront(element)
+		return sanitizer
+	}
+	r.entries[tenantID] = r.lru.PushFront(&registryEntry{tenantID: tenantID, sanitizer: sanitizer})
+	if r.capacity > 0 && r.lru.Len() > r.capacity {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*registryEntry).tenantID)
+	}
+	return sanitizer
+}
+
+// Invalidate removes the cached sanitizer of the given tenant, so it is derived again on the next Get.
+func (r *Registry) Invalidate(tenantID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if element, ok := r.entries[tenantID]; ok {
+		r.lru.Remove(element)
+		delete(r.entries, tenantID)
+	}
+}
+
+// Len returns the number of cached sanitizers.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+func (r *Registry) cached(tenantID string) (*ProfanitySanitizer, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.entries[tenantID]
+	if !ok {
+		return nil, false
+	}
+	r.lru.MoveToFront(element)
+	return element.Value.(*registryEntry).sanitizer, true
+}
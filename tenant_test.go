@@ -0,0 +1,122 @@
+package goclean
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProfanitySanitizer_Derive(t *testing.T) {
+	base := NewProfanitySanitizer(DefaultConfig())
+	derived, err := base.Derive(Layer{
+		Profanities:          []WordMatcher{{Word: "heck"}},
+		FalsePositives:       []string{"fuchsia"},
+		FalseNegatives:       []WordMatcher{{Word: "bassface"}},
+		ReplacementCharacter: "#",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name        string
+		text        string
+		wantBase    string
+		wantDerived string
+	}{
+		{"base profanity", "shit", "****", "####"},
+		{"added profanity", "what the heck", "what the heck", "what the ####"},
+		{"added obfuscated profanity", "h.e.c.k", "h.e.c.k", "#######"},
+		{"added false negative", "bassface", "bassface", "########"},
+		{"base false positive", "bass", "bass", "bass"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := base.Redact(test.text); got != test.wantBase {
+				t.Errorf("base: got %s, want %s", got, test.wantBase)
+			}
+			if got := derived.Redact(test.text); got != test.wantDerived {
+				t.Errorf("derived: got %s, want %s", got, test.wantDerived)
+			}
+		})
+	}
+	if &base.config.Profanities[0] == &derived.config.Profanities[0] {
+		t.Errorf("derived sanitizer should not share the backing array with the base")
+	}
+	if base.config.Profanities[0].Matcher != derived.config.Profanities[0].Matcher {
+		t.Errorf("derived sanitizer should share compiled matchers with the base")
+	}
+}
+
+func TestProfanitySanitizer_DeriveInvalidRegex(t *testing.T) {
+	base := NewProfanitySanitizer(DefaultConfig())
+	tests := []struct {
+		name  string
+		layer Layer
+	}{
+		{"profanity", Layer{Profanities: []WordMatcher{{Word: "heck", Regex: "h[e+ck"}}}},
+		{"false negative", Layer{FalseNegatives: []WordMatcher{{Regex: "(bassface"}}}},
+		{"false positive", Layer{FalsePositives: []string{"fuchsia("}}},
+		{"emoji", Layer{Emoji: []WordMatcher{{Word: "💩", Regex: "💩["}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := base.Derive(test.layer); err == nil {
+				t.Errorf("expected error for an invalid regex")
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	loads := make(map[string]int)
+	registry := NewRegistry(NewProfanitySanitizer(DefaultConfig()), 2, func(tenantID string) (Layer, error) {
+		loads[tenantID]++
+		switch tenantID {
+		case "broken":
+			return Layer{}, errors.New("unknown tenant")
+		case "invalid":
+			return Layer{Profanities: []WordMatcher{{Regex: "h[e+ck"}}}, nil
+		}
+		return Layer{Profanities: []WordMatcher{{Word: tenantID}}}, nil
+	})
+
+	first, err := registry.Get("heck")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !first.IsProfane("heck") {
+		t.Errorf("tenant sanitizer should detect the tenant profanity")
+	}
+	if registry.Base().IsProfane("heck") {
+		t.Errorf("base sanitizer should not detect the tenant profanity")
+	}
+	if again, _ := registry.Get("heck"); again != first {
+		t.Errorf("sanitizer should be cached")
+	}
+	_, _ = registry.Get("darn")
+	_, _ = registry.Get("heck")
+	_, _ = registry.Get("frick")
+	if registry.Len() != 2 {
+		t.Errorf("got %d cached sanitizers, want 2", registry.Len())
+	}
+	_, _ = registry.Get("darn")
+	if loads["darn"] != 2 || loads["heck"] != 1 {
+		t.Errorf("least recently used sanitizer should be evicted, got loads %v", loads)
+	}
+
+	registry.Invalidate("heck")
+	_, _ = registry.Get("heck")
+	if loads["heck"] != 2 {
+		t.Errorf("invalidated sanitizer should be loaded again, got %d loads", loads["heck"])
+	}
+	if _, err := registry.Get("broken"); err == nil {
+		t.Errorf("expected loader error")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := registry.Get("invalid"); err == nil {
+			t.Errorf("expected error for an invalid regex")
+		}
+	}
+	if loads["invalid"] != 2 {
+		t.Errorf("failed sanitizer should not be cached, got %d loads", loads["invalid"])
+	}
+}
@@ -0,0 +1,89 @@
+package goclean
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// FieldLevel is the part of the FieldLevel interface of github.com/go-playground/validator used by ValidateField,
+// so the validation can be registered without go-clean depending on the validator:
+//
+//	clean := func(fl validator.FieldLevel) bool { return goclean.ValidateField(fl) }
+//	validate.RegisterValidation("clean", clean)
+//	validate.RegisterValidation("clean_level", clean)
+type FieldLevel interface {
+	Field() reflect.Value
+	Param() string
+}
+
+// ValidateField reports whether the field is free of profanities. It's meant to be registered as the
+// validation function of a "clean" and "clean_level" rule, the param of the rule is the minimal level of
+// the rejected concerns, e.g. `validate:"clean_level=2"`. Concerns of dictionary entries without a level
+// have level 1.
+//
+// Strings, pointers to strings and slices, arrays and maps of strings are checked, structs are checked
+// using their goclean tags, see Validate. It panics if the param is not a number.
+func (gc *ProfanitySanitizer) ValidateField(fl FieldLevel) bool {
+	var level int32
+	if param := fl.Param(); param != "" {
+		parsed, err := strconv.ParseInt(param, 10, 32)
+		if err != nil {
+			panic(fmt.Sprintf("goclean: invalid level %q of the clean validation", param))
+		}
+		level = int32(parsed)
+	}
+	fields, err := gc.validateValue(fl.Field(), level)
+	return err == nil && len(fields) == 0
+}
+
+// Validator validates a single value, it's the interface of custom rules used by many validation frameworks.
+type Validator interface {
+	Validate(value interface{}) error
+}
+
+// Rule is a Validator that rejects values with profanities of at least Level. Concerns of dictionary entries
+// without a level have level 1.
+//
+// The value can be a string, a pointer, a slice, an array or a map of strings, or a struct that is checked using
+// its goclean tags, see Validate. A string with profanities is rejected with *FieldError with an empty path,
+// other values with *ValidationError.
+type Rule struct {
+	// Sanitizer is used to detect the profanities, the default ProfanitySanitizer is used if it's nil.
+	Sanitizer *ProfanitySanitizer
+	Level     int32
+}
+
+// Validate checks the value for profanities.
+func (r Rule) Validate(value interface{}) error {
+	sanitizer := r.Sanitizer
+	if sanitizer == nil {
+		sanitizer = &gc
+	}
+	fields, err := sanitizer.validateValue(reflect.ValueOf(value), r.Level)
+	switch {
+	case err != nil:
+		return err
+	case len(fields) == 1 && fields[0].Path == "":
+		return &fields[0]
+	case len(fields) > 0:
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validateValue checks a value as if it were a field tagged with `goclean:"reject,level=N"`.
+func (gc *ProfanitySanitizer) validateValue(v reflect.Value, level int32) ([]FieldError, error) {
+	w := &structWalker{sanitizer: gc, visited: make(map[visit]bool)}
+	if err := w.walk(v, "", &fieldRule{reject: true, level: level}); err != nil {
+		return nil, err
+	}
+	return w.fields, nil
+}
+
+// ValidateField reports whether the field is free of profanities.
+//
+// Uses the default ProfanitySanitizer
+func ValidateField(fl FieldLevel) bool {
+	return gc.ValidateField(fl)
+}
@@ -0,0 +1,106 @@
+package goclean
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubFieldLevel implements FieldLevel like validator.FieldLevel does.
+type stubFieldLevel struct {
+	field reflect.Value
+	param string
+}
+
+func (fl stubFieldLevel) Field() reflect.Value { return fl.field }
+func (fl stubFieldLevel) Param() string        { return fl.param }
+
+// stubValidator mimics the registration and the `validate:"rule=param"` tags of go-playground/validator.
+type stubValidator struct {
+	rules map[string]func(fl FieldLevel) bool
+}
+
+func (v *stubValidator) RegisterValidation(tag string, fn func(fl FieldLevel) bool) {
+	v.rules[tag] = fn
+}
+
+func (v *stubValidator) Struct(s interface{}) []string {
+	var failed []string
+	value := reflect.ValueOf(s)
+	for i := 0; i < value.NumField(); i++ {
+		tag := value.Type().Field(i).Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(tag, "=")
+		if !v.rules[name](stubFieldLevel{field: value.Field(i), param: param}) {
+			failed = append(failed, value.Type().Field(i).Name)
+		}
+	}
+	return failed
+}
+
+func TestGoClean_ValidateField(t *testing.T) {
+	validate := &stubValidator{rules: make(map[string]func(fl FieldLevel) bool)}
+	validate.RegisterValidation("clean", ValidateField)
+	validate.RegisterValidation("clean_level", ValidateField)
+
+	type request struct {
+		Name     string   `validate:"clean"`
+		Nickname *string  `validate:"clean_level=2"`
+		Title    string   `validate:"clean_level=1"`
+		Tags     []string `validate:"clean"`
+		Bio      string
+	}
+	crap, ass := "crap", "a.s.s"
+	tests := []struct {
+		name    string
+		request request
+		want    []string
+	}{
+		{"clean", request{Name: "hello", Tags: []string{"music"}, Bio: "shit"}, nil},
+		{"profane string", request{Name: "fûçk"}, []string{"Name"}},
+		{"below level", request{Nickname: &crap}, nil},
+		{"at level", request{Nickname: &ass}, []string{"Nickname"}},
+		{"without a level", request{Title: "fuck"}, []string{"Title"}},
+		{"profane slice", request{Tags: []string{"music", "shit"}}, []string{"Tags"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := validate.Struct(test.request); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRule(t *testing.T) {
+	var validator Validator = Rule{Level: 2}
+	if err := validator.Validate("crap"); err != nil {
+		t.Errorf("got %v, want no error below the level", err)
+	}
+	err := validator.Validate("dumbass")
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "" || fieldErr.Concerns[0].MatchedText != "dumbass" {
+		t.Fatalf("got %v, want FieldError", err)
+	}
+	if err.Error() != `goclean: value contains profanity "dumbass"` {
+		t.Errorf("got %s", err)
+	}
+
+	// "fuck" has no level in the default config
+	if err := (Rule{Level: 1}).Validate("fuck"); err == nil {
+		t.Errorf("got no error, want concerns without a level to have level 1")
+	}
+	if err := (Rule{Level: 2}).Validate("fuck"); err != nil {
+		t.Errorf("got %v, want no error below the level", err)
+	}
+
+	custom := NewProfanitySanitizer(&Config{Profanities: []WordMatcher{{Word: "heck"}}})
+	err = Rule{Sanitizer: &custom}.Validate([]string{"hello", "heck"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Fields[0].Path != "[1]" {
+		t.Errorf("got %v, want ValidationError", err)
+	}
+}
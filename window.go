@@ -0,0 +1,141 @@
+package goclean
+
+import (
+	"sync"
+	"time"
+)
+
+// WindowOptions configures a ConversationWindow.
+type WindowOptions struct {
+	// ConversationOptions limit how words are spelled across the messages of the window.
+	ConversationOptions
+	// Size is the maximum number of recent messages kept for each conversation. Defaults to 8.
+	Size int
+	// TTL is the time after which a message is evicted from the window. Zero means messages are only evicted
+	// when the window is full.
+	TTL time.Duration
+}
+
+// ConversationWindow keeps a bounded window of recent messages for each conversation (or user) and detects
+// words spelled across them, like ListConversation, when messages are added. It is safe for concurrent use.
+type ConversationWindow struct {
+	sanitizer *ProfanitySanitizer
+	opts      WindowOptions
+	now       func() time.Time
+
+	mu            sync.Mutex
+	conversations map[string]*conversationWindow
+	lastSweep     time.Time
+}
+
+type conversationWindow struct {
+	messages []windowMessage
+	// next is the sequence number of the next message of the conversation
+	next int
+}
+
+type windowMessage struct {
+	text  string
+	added time.Time
+}
+
+// NewConversationWindow creates a new ConversationWindow that detects profanities with the given sanitizer.
+func NewConversationWindow(sanitizer *ProfanitySanitizer, opts WindowOptions) *ConversationWindow {
+	if opts.Size <= 0 {
+		opts.Size = defaultMaxMessages
+	}
+	return &ConversationWindow{
+		sanitizer:     sanitizer,
+		opts:          opts,
+		now:           time.Now,
+		conversations: make(map[string]*conversationWindow),
+	}
+}
+
+// Add adds a message to the window of the conversation and returns the concerns that end in the message,
+// i.e. the profanities the message completes, including the ones within the message itself.
+//
+// StartMessage and EndMessage of the concerns are the sequence numbers of the messages in the conversation,
+// the first message added to a conversation is 0. Sequence numbers start again at 0 once all messages
+// of a conversation are evicted.
+func (w *ConversationWindow) Add(conversationID, message string) []ConversationConcern {
+	messages, first := w.add(conversationID, message)
+	concerns := w.sanitizer.ListConversation(messages, w.opts.ConversationOptions)
+	latest := make([]ConversationConcern, 0, len(concerns))
+	for _, concern := range concerns {
+		if concern.EndMessage != len(messages)-1 {
+			continue
+		}
+		concern.StartMessage += first
+		concern.EndMessage += first
+		latest = append(latest, concern)
+	}
+	return latest
+}
+
+// add appends the message to the window and returns a copy of the messages in the window
+// with the sequence number of the first one.
+func (w *ConversationWindow) add(conversationID, message string) ([]string, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	w.sweep(now)
+	c, ok := w.conversations[conversationID]
+	if !ok {
+		c = &conversationWindow{}
+		w.conversations[conversationID] = c
+	}
+	w.evictExpired(c, now)
+	c.messages = append(c.messages, windowMessage{text: message, added: now})
+	c.next++
+	if len(c.messages) > w.opts.Size {
+		c.messages = append(c.messages[:0], c.messages[len(c.messages)-w.opts.Size:]...)
+	}
+	messages := make([]string, len(c.messages))
+	for i, m := range c.messages {
+		messages[i] = m.text
+	}
+	return messages, c.next - len(messages)
+}
+
+// sweep removes the conversations whose messages are all expired, at most once per TTL.
+func (w *ConversationWindow) sweep(now time.Time) {
+	if w.opts.TTL <= 0 || now.Sub(w.lastSweep) < w.opts.TTL {
+		return
+	}
+	w.lastSweep = now
+	for id, c := range w.conversations {
+		if w.evictExpired(c, now); len(c.messages) == 0 {
+			delete(w.conversations, id)
+		}
+	}
+}
+
+// evictExpired removes the messages older than the TTL, a conversation without messages starts again at 0.
+func (w *ConversationWindow) evictExpired(c *conversationWindow, now time.Time) {
+	if w.opts.TTL <= 0 {
+		return
+	}
+	i := 0
+	for i < len(c.messages) && now.Sub(c.messages[i].added) >= w.opts.TTL {
+		i++
+	}
+	c.messages = append(c.messages[:0], c.messages[i:]...)
+	if len(c.messages) == 0 {
+		c.next = 0
+	}
+}
+
+// Reset removes all messages of the conversation.
+func (w *ConversationWindow) Reset(conversationID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.conversations, conversationID)
+}
+
+// Len returns the number of conversations in the window.
+func (w *ConversationWindow) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.conversations)
+}
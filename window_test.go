@@ -0,0 +1,103 @@
+package goclean
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConversationWindow(t *testing.T) {
+	sanitizer := NewProfanitySanitizer(DefaultConfig())
+	window := NewConversationWindow(&sanitizer, WindowOptions{Size: 4, TTL: time.Minute})
+	now := time.Unix(0, 0)
+	window.now = func() time.Time { return now }
+
+	if got := window.Add("alice", "sh"); len(got) != 0 {
+		t.Errorf("got %v, want no concerns", got)
+	}
+	if got := window.Add("bob", "it"); len(got) != 0 {
+		t.Errorf("conversations should be separate, got %v", got)
+	}
+	got := window.Add("alice", "it")
+	want := []ConversationConcern{
+		{DetectedConcern: DetectedConcern{Word: "shit", MatchedText: "shit", StartIndex: 0, EndIndex: 2}, StartMessage: 0, EndMessage: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := window.Add("alice", "s"); len(got) != 0 {
+		t.Errorf("completed concerns should not be reported again, got %v", got)
+	}
+
+	got = window.Add("alice", "what the fuck")
+	want = []ConversationConcern{
+		{DetectedConcern: DetectedConcern{MatchedText: "fuck", StartIndex: 9, EndIndex: 13}, StartMessage: 3, EndMessage: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for _, message := range []string{"f", "u", "c"} {
+		window.Add("alice", message)
+	}
+	got = window.Add("alice", "k")
+	if len(got) != 1 || got[0].StartMessage != 4 || got[0].EndMessage != 7 {
+		t.Errorf("got %+v, want fuck in messages 4 to 7", got)
+	}
+	if n := len(window.conversations["alice"].messages); n != 4 {
+		t.Errorf("got %d messages in the window, want 4", n)
+	}
+
+	window.Add("alice", "a")
+	now = now.Add(2 * time.Minute)
+	if got := window.Add("alice", "ss"); len(got) != 0 {
+		t.Errorf("expired messages should be evicted, got %v", got)
+	}
+	if window.Len() != 1 {
+		t.Errorf("got %d conversations, want the expired conversation evicted", window.Len())
+	}
+	window.Reset("alice")
+	if window.Len() != 0 {
+		t.Errorf("got %d conversations, want 0", window.Len())
+	}
+}
+
+func TestConversationWindow_LargerThanMessageLimit(t *testing.T) {
+	sanitizer := NewProfanitySanitizer(DefaultConfig())
+	window := NewConversationWindow(&sanitizer, WindowOptions{Size: 10, ConversationOptions: ConversationOptions{MaxMessages: 4}})
+	var got []ConversationConcern
+	for _, message := range []string{"a", "b", "c", "d", "e", "f", "s", "h", "i", "t"} {
+		got = append(got, window.Add("alice", message)...)
+	}
+	want := []ConversationConcern{
+		{DetectedConcern: DetectedConcern{Word: "shit", MatchedText: "shit", StartIndex: 0, EndIndex: 1}, StartMessage: 6, EndMessage: 9},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestConversationWindow_Concurrent(t *testing.T) {
+	sanitizer := NewProfanitySanitizer(DefaultConfig())
+	window := NewConversationWindow(&sanitizer, WindowOptions{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				window.Add(id, "sh")
+				if got := window.Add(id, "it"); len(got) != 1 {
+					t.Errorf("got %v, want one concern", got)
+					return
+				}
+			}
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+	if window.Len() != 8 {
+		t.Errorf("got %d conversations, want 8", window.Len())
+	}
+}